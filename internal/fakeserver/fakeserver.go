@@ -0,0 +1,496 @@
+// Package fakeserver is a fake PagerDuty REST API, for testing the plugin's
+// tables without a PagerDuty account.
+//
+// Resources are seeded under the path they are listed at, e.g. "/incidents"
+// or "/incidents/PIJ90N7/log_entries". A list path serves the resources with
+// offset pagination and the common filters, and "<list path>/<id>" serves a
+// single resource.
+package fakeserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultLimit is the page size when a request has no limit
+	DefaultLimit = 25
+
+	// MaxLimit is the largest page size the API returns
+	MaxLimit = 100
+
+	// MaxOffset is the furthest the API pages through a list by offset
+	MaxOffset = 10000
+)
+
+// includes are the include[] values the server expands, with the field of
+// the reference and the name of the list the full objects are seeded in
+var includes = map[string]struct {
+	field []string
+	list  string
+}{
+	"acknowledgers":             {field: []string{"acknowledgements", "acknowledger"}, list: "users"},
+	"assignees":                 {field: []string{"assignments", "assignee"}, list: "users"},
+	"escalation_policies":       {field: []string{"escalation_policy"}, list: "escalation_policies"},
+	"first_trigger_log_entries": {field: []string{"first_trigger_log_entry"}, list: "log_entries"},
+	"incidents":                 {field: []string{"incident"}, list: "incidents"},
+	"priorities":                {field: []string{"priority"}, list: "priorities"},
+	"services":                  {field: []string{"service"}, list: "services"},
+	"teams":                     {field: []string{"teams"}, list: "teams"},
+}
+
+// Object is a seeded resource, as decoded from its JSON representation
+type Object = map[string]interface{}
+
+// Request is a request received by the server
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+}
+
+// Server is a fake PagerDuty REST API server
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	collections map[string][]Object
+	unpaginated map[string]bool
+	names       map[string]string
+	failures    map[string][]failure
+	requests    []Request
+	delay       time.Duration
+	inFlight    int
+	maxInFlight int
+}
+
+type failure struct {
+	status int
+	code   int
+}
+
+// New starts a fake server with no resources. Close it when done.
+func New() *Server {
+	s := &Server{
+		collections: map[string][]Object{},
+		unpaginated: map[string]bool{},
+		names:       map[string]string{},
+		failures:    map[string][]failure{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Seed adds resources to the list at path. Each item is encoded as JSON, so
+// it can be a go-pagerduty struct, a map or anything else with an "id".
+func (s *Server) Seed(listPath string, items ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	listPath = cleanPath(listPath)
+	if _, ok := s.collections[listPath]; !ok {
+		s.collections[listPath] = []Object{}
+	}
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			panic(fmt.Sprintf("fakeserver: failed to encode %T: %v", item, err))
+		}
+		var object Object
+		if err := json.Unmarshal(data, &object); err != nil {
+			panic(fmt.Sprintf("fakeserver: %T is not a JSON object: %v", item, err))
+		}
+		s.collections[listPath] = append(s.collections[listPath], object)
+	}
+}
+
+// Unpaginated makes the list at path serve every resource in one response,
+// without the pagination fields, like the endpoints that aren't paginated
+func (s *Server) Unpaginated(listPath string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.unpaginated[cleanPath(listPath)] = true
+}
+
+// Named makes the list at path serve its resources under name, like the
+// endpoints whose list isn't named after their path, e.g. "fields" for
+// "/incidents/custom_fields"
+func (s *Server) Named(listPath string, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.names[cleanPath(listPath)] = name
+}
+
+// Fail makes the next times requests to path fail with the HTTP status. A 429
+// response has a Retry-After header of zero, so that retries aren't delayed.
+func (s *Server) Fail(requestPath string, status int, times int) {
+	s.FailWithCode(requestPath, status, 0, times)
+}
+
+// FailWithCode is like Fail, with a PagerDuty error code in the response
+func (s *Server) FailWithCode(requestPath string, status int, code int, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requestPath = cleanPath(requestPath)
+	for i := 0; i < times; i++ {
+		s.failures[requestPath] = append(s.failures[requestPath], failure{status: status, code: code})
+	}
+}
+
+// Delay makes every response wait for d, so that concurrent requests overlap
+func (s *Server) Delay(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.delay = d
+}
+
+// MaxInFlight returns the most requests the server has handled at once
+func (s *Server) MaxInFlight() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.maxInFlight
+}
+
+// Requests returns the requests received for path, oldest first
+func (s *Server) Requests(requestPath string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requestPath = cleanPath(requestPath)
+	var requests []Request
+	for _, r := range s.requests {
+		if r.Path == requestPath {
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	requestPath := cleanPath(r.URL.Path)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: requestPath, Query: r.URL.Query()})
+	s.inFlight++
+	if s.inFlight > s.maxInFlight {
+		s.maxInFlight = s.inFlight
+	}
+	delay := s.delay
+	var failed *failure
+	if failures := s.failures[requestPath]; len(failures) > 0 {
+		failed = &failures[0]
+		s.failures[requestPath] = failures[1:]
+	}
+	list, isList := s.collections[requestPath]
+	list = append([]Object(nil), list...)
+	unpaginated := s.unpaginated[requestPath]
+	name, named := s.names[requestPath]
+	if !named {
+		name = path.Base(requestPath)
+	}
+	parent, id := path.Split(requestPath)
+	siblings, isItem := s.collections[cleanPath(parent)]
+	list = s.expand(list, r.URL.Query()["include[]"])
+	siblings = s.expand(siblings, r.URL.Query()["include[]"])
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.inFlight--
+		s.mu.Unlock()
+	}()
+	time.Sleep(delay)
+
+	switch {
+	case failed != nil:
+		if failed.status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		writeError(w, failed.status, failed.code, http.StatusText(failed.status))
+	case r.Method != http.MethodGet:
+		writeError(w, http.StatusMethodNotAllowed, 0, "Method Not Allowed")
+	case isList && unpaginated:
+		writeJSON(w, http.StatusOK, map[string]interface{}{name: list})
+	case isList:
+		s.writeList(w, r, name, list)
+	case isItem:
+		for _, object := range siblings {
+			if matchesID(object, id) {
+				writeJSON(w, http.StatusOK, map[string]interface{}{singular(path.Base(parent)): object})
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, 2100, "Not Found")
+	default:
+		writeError(w, http.StatusNotFound, 2100, "Not Found")
+	}
+}
+
+func (s *Server) writeList(w http.ResponseWriter, r *http.Request, name string, objects []Object) {
+	query := r.URL.Query()
+
+	limit := DefaultLimit
+	if value := query.Get("limit"); value != "" {
+		limit, _ = strconv.Atoi(value)
+	}
+	if limit <= 0 || limit > MaxLimit {
+		limit = MaxLimit
+	}
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	if offset+limit > MaxOffset {
+		writeError(w, http.StatusBadRequest, 2001, fmt.Sprintf("Offset must be less than %d", MaxOffset-limit+1))
+		return
+	}
+
+	var matched []Object
+	for _, object := range objects {
+		if matches(object, query) {
+			matched = append(matched, object)
+		}
+	}
+	sortObjects(matched, query.Get("sort_by"))
+
+	page := []Object{}
+	if offset < len(matched) {
+		end := offset + limit
+		if end > len(matched) {
+			end = len(matched)
+		}
+		page = matched[offset:end]
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		name:     page,
+		"limit":  limit,
+		"offset": offset,
+		"more":   offset+limit < len(matched),
+		"total":  len(matched),
+	})
+}
+
+// expand returns the objects with the references named in include replaced by
+// the full objects, like the API's include[] parameter. The seeded objects
+// are left unchanged. It must be called with the lock held.
+func (s *Server) expand(objects []Object, include []string) []Object {
+	if len(include) == 0 {
+		return objects
+	}
+	expanded := make([]Object, 0, len(objects))
+	for _, object := range objects {
+		for _, name := range include {
+			if inc, ok := includes[name]; ok {
+				object = s.expandField(object, inc.field, inc.list).(Object)
+			}
+		}
+		expanded = append(expanded, object)
+	}
+	return expanded
+}
+
+// expandField returns a copy of value with the references at the field path
+// replaced by the full objects seeded in lists named list
+func (s *Server) expandField(value interface{}, field []string, list string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(field) == 0 {
+			if id, ok := v["id"].(string); ok {
+				if full := s.find(list, id); full != nil {
+					return full
+				}
+			}
+			return v
+		}
+		if _, ok := v[field[0]]; !ok {
+			return v
+		}
+		copied := Object{}
+		for key, item := range v {
+			copied[key] = item
+		}
+		copied[field[0]] = s.expandField(v[field[0]], field[1:], list)
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			copied = append(copied, s.expandField(item, field, list))
+		}
+		return copied
+	}
+	return value
+}
+
+// find returns the object with the id in any list named list, or nil
+func (s *Server) find(list string, id string) Object {
+	for listPath, objects := range s.collections {
+		if path.Base(listPath) != list {
+			continue
+		}
+		for _, object := range objects {
+			if object["id"] == id {
+				return object
+			}
+		}
+	}
+	return nil
+}
+
+// matches returns true if the object passes the filters in the query
+func matches(object Object, query url.Values) bool {
+	if q := strings.ToLower(query.Get("query")); q != "" {
+		found := false
+		for _, field := range []string{"name", "label", "email", "summary"} {
+			if value, ok := object[field].(string); ok && strings.Contains(strings.ToLower(value), q) {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if key := query.Get("incident_key"); key != "" && object["incident_key"] != key {
+		return false
+	}
+	if key := query.Get("alert_key"); key != "" && object["alert_key"] != key {
+		return false
+	}
+
+	filters := map[string][]string{
+		"statuses[]":              {"status"},
+		"urgencies[]":             {"urgency"},
+		"service_ids[]":           {"service", "id"},
+		"team_ids[]":              {"teams", "id"},
+		"user_ids[]":              {"assignments", "assignee", "id"},
+		"escalation_policy_ids[]": {"escalation_policy", "id"},
+	}
+	for param, field := range filters {
+		if wanted := query[param]; len(wanted) > 0 && !containsAny(lookup(object, field), wanted) {
+			return false
+		}
+	}
+
+	if since, err := time.Parse(time.RFC3339, query.Get("since")); err == nil {
+		if createdAt, ok := createdAt(object); ok && createdAt.Before(since) {
+			return false
+		}
+	}
+	if until, err := time.Parse(time.RFC3339, query.Get("until")); err == nil {
+		if createdAt, ok := createdAt(object); ok && !createdAt.Before(until) {
+			return false
+		}
+	}
+	return true
+}
+
+// lookup returns the values at the field path, flattening any arrays
+func lookup(value interface{}, field []string) []string {
+	if len(field) == 0 {
+		if s, ok := value.(string); ok {
+			return []string{s}
+		}
+		return nil
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return lookup(v[field[0]], field[1:])
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			values = append(values, lookup(item, field)...)
+		}
+		return values
+	}
+	return nil
+}
+
+func containsAny(values []string, wanted []string) bool {
+	for _, value := range values {
+		for _, w := range wanted {
+			if value == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func createdAt(object Object) (time.Time, bool) {
+	value, ok := object["created_at"].(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, err == nil
+}
+
+// sortObjects sorts by created_at, if requested. Otherwise the seeded order
+// is kept.
+func sortObjects(objects []Object, sortBy string) {
+	field, direction, _ := strings.Cut(sortBy, ":")
+	if field != "created_at" {
+		return
+	}
+	sort.SliceStable(objects, func(i, j int) bool {
+		a, _ := createdAt(objects[i])
+		b, _ := createdAt(objects[j])
+		if direction == "desc" {
+			return a.After(b)
+		}
+		return a.Before(b)
+	})
+}
+
+// matchesID returns true if the object has the id, or the incident number
+func matchesID(object Object, id string) bool {
+	if object["id"] == id {
+		return true
+	}
+	if number, ok := object["incident_number"].(float64); ok && strconv.FormatFloat(number, 'f', -1, 64) == id {
+		return true
+	}
+	return false
+}
+
+// singular returns the name of a single resource of the list, e.g.
+// "escalation_policy" for "escalation_policies"
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "s"):
+		return strings.TrimSuffix(name, "s")
+	}
+	return name
+}
+
+func cleanPath(p string) string {
+	return "/" + strings.Trim(p, "/")
+}
+
+func writeError(w http.ResponseWriter, status int, code int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,244 @@
+package fakeserver
+
+// Subdomain is the account of the resources seeded by SeedFixtures
+const Subdomain = "acme"
+
+// IDs of the resources seeded by SeedFixtures
+const (
+	TeamID             = "PTEAM01"
+	UserID             = "PUSER01"
+	SecondUserID       = "PUSER02"
+	ScheduleID         = "PSCHED1"
+	EscalationPolicyID = "PESCAL1"
+	ServiceID          = "PSERV01"
+	IntegrationID      = "PINTEG1"
+	IncidentID         = "PINC001"
+	SecondIncidentID   = "PINC002"
+	LogEntryID         = "PLOG001"
+	SecondLogEntryID   = "PLOG002"
+	AlertID            = "PALERT1"
+	SecondAlertID      = "PALERT2"
+	NoteID             = "PNOTE01"
+	PriorityID         = "PPRIO01"
+	RulesetID          = "0d6c5b2a-1e3f-4a5b-8c9d-0e1f2a3b4c5d"
+	RulesetRuleID      = "6e2b7c1d-4f5a-4b6c-9d8e-7f6a5b4c3d2e"
+	TagID              = "PTAG001"
+	VendorID           = "PVEND01"
+)
+
+// SeedFixtures seeds a small account, with one or two of each resource
+func (s *Server) SeedFixtures() {
+	team := Object{"id": TeamID, "type": "team_reference", "summary": "Platform"}
+	user := Object{"id": UserID, "type": "user_reference", "summary": "Ada Lovelace"}
+	schedule := Object{"id": ScheduleID, "type": "schedule_reference", "summary": "Primary"}
+	escalationPolicy := Object{"id": EscalationPolicyID, "type": "escalation_policy_reference", "summary": "Platform On-Call"}
+	service := Object{"id": ServiceID, "type": "service_reference", "summary": "API Gateway"}
+	vendor := Object{"id": VendorID, "type": "vendor_reference", "summary": "Datadog"}
+	priority := Object{"id": PriorityID, "type": "priority_reference", "summary": "P1"}
+	logEntry := Object{"id": LogEntryID, "type": "trigger_log_entry_reference", "summary": "Triggered through the API"}
+	integration := Object{
+		"id":         IntegrationID,
+		"type":       "generic_events_api_inbound_integration",
+		"name":       "Datadog",
+		"service":    service,
+		"vendor":     vendor,
+		"created_at": "2023-01-10T09:00:00Z",
+	}
+
+	s.Seed("/teams",
+		Object{"id": TeamID, "type": "team", "name": "Platform", "summary": "Platform", "description": "Platform engineering"},
+	)
+	s.Seed("/teams/"+TeamID+"/members",
+		Object{"user": user, "role": "manager"},
+	)
+	s.Seed("/teams/"+TeamID+"/tags",
+		Object{"id": TagID, "type": "tag", "label": "production"},
+	)
+
+	s.Seed("/users",
+		Object{"id": UserID, "type": "user", "name": "Ada Lovelace", "summary": "Ada Lovelace", "email": "ada@example.com", "role": "admin", "time_zone": "Europe/London", "teams": []Object{team}, "html_url": webURL("users", UserID)},
+		Object{"id": SecondUserID, "type": "user", "name": "Grace Hopper", "summary": "Grace Hopper", "email": "grace@example.com", "role": "user", "time_zone": "America/New_York", "html_url": webURL("users", SecondUserID)},
+	)
+	s.Seed("/users/" + UserID + "/tags")
+	s.Seed("/users/" + SecondUserID + "/tags")
+
+	s.Seed("/schedules",
+		Object{"id": ScheduleID, "type": "schedule", "name": "Primary", "summary": "Primary", "time_zone": "Europe/London", "users": []Object{user}, "escalation_policies": []Object{escalationPolicy}, "teams": []Object{team}},
+	)
+	s.Seed("/schedules/"+ScheduleID+"/users",
+		Object{"id": UserID, "type": "user", "name": "Ada Lovelace", "email": "ada@example.com"},
+	)
+	s.Unpaginated("/schedules/" + ScheduleID + "/users")
+
+	s.Seed("/escalation_policies",
+		Object{"id": EscalationPolicyID, "type": "escalation_policy", "name": "Platform On-Call", "summary": "Platform On-Call", "num_loops": 1, "services": []Object{service}, "teams": []Object{team}},
+	)
+	s.Seed("/escalation_policies/"+EscalationPolicyID+"/tags",
+		Object{"id": TagID, "type": "tag", "label": "production"},
+	)
+
+	s.Seed("/services",
+		Object{"id": ServiceID, "type": "service", "name": "API Gateway", "summary": "API Gateway", "status": "active", "escalation_policy": escalationPolicy, "teams": []Object{team}, "integrations": []Object{integration}, "created_at": "2023-01-10T09:00:00Z"},
+	)
+	s.Seed("/services/"+ServiceID+"/integrations", integration)
+
+	s.Seed("/incidents",
+		Object{
+			"id":                      IncidentID,
+			"type":                    "incident",
+			"incident_number":         1,
+			"title":                   "API latency above threshold",
+			"summary":                 "[#1] API latency above threshold",
+			"status":                  "resolved",
+			"urgency":                 "high",
+			"incident_key":            "latency-1",
+			"created_at":              "2024-03-01T10:00:00Z",
+			"service":                 service,
+			"escalation_policy":       escalationPolicy,
+			"teams":                   []Object{team},
+			"assignments":             []Object{},
+			"acknowledgements":        []Object{},
+			"priority":                priority,
+			"first_trigger_log_entry": logEntry,
+			"conference_bridge":       Object{"conference_number": "+1 415-555-0100,,123456#", "conference_url": "https://meet.example.com/incident-1"},
+		},
+		Object{
+			"id":                SecondIncidentID,
+			"type":              "incident",
+			"incident_number":   2,
+			"title":             "Disk usage above threshold",
+			"summary":           "[#2] Disk usage above threshold",
+			"status":            "triggered",
+			"urgency":           "low",
+			"incident_key":      "disk-2",
+			"created_at":        "2024-03-02T10:00:00Z",
+			"service":           service,
+			"escalation_policy": escalationPolicy,
+			"teams":             []Object{team},
+			"assignments":       []Object{{"at": "2024-03-02T10:00:00Z", "assignee": user}},
+			"acknowledgements":  []Object{},
+		},
+	)
+	firstLogEntry := Object{
+		"id":         LogEntryID,
+		"type":       "trigger_log_entry",
+		"summary":    "Triggered through the API",
+		"created_at": "2024-03-01T10:00:00Z",
+		"agent":      service,
+		"channel":    Object{"type": "api"},
+		"incident":   Object{"id": IncidentID, "type": "incident_reference"},
+		"service":    service,
+		"teams":      []Object{team},
+	}
+	secondLogEntry := Object{
+		"id":         SecondLogEntryID,
+		"type":       "trigger_log_entry",
+		"summary":    "Triggered through the API",
+		"created_at": "2024-03-02T10:00:00Z",
+		"agent":      service,
+		"channel":    Object{"type": "api"},
+		"incident":   Object{"id": SecondIncidentID, "type": "incident_reference"},
+		"service":    service,
+		"teams":      []Object{team},
+	}
+	s.Seed("/incidents/"+IncidentID+"/log_entries", firstLogEntry)
+	s.Seed("/incidents/" + SecondIncidentID + "/log_entries")
+	s.Seed("/log_entries", firstLogEntry, secondLogEntry)
+	s.Seed("/incidents/"+IncidentID+"/notes",
+		Object{"id": NoteID, "user": user, "content": "Rolled back the latest deploy", "created_at": "2024-03-01T10:20:00Z"},
+	)
+	s.Unpaginated("/incidents/" + IncidentID + "/notes")
+	s.Seed("/incidents/" + SecondIncidentID + "/notes")
+	s.Unpaginated("/incidents/" + SecondIncidentID + "/notes")
+	s.Seed("/incidents/"+IncidentID+"/alerts",
+		Object{
+			"id":          AlertID,
+			"type":        "alert",
+			"summary":     "API latency above threshold",
+			"status":      "resolved",
+			"severity":    "critical",
+			"alert_key":   "latency-1",
+			"suppressed":  false,
+			"created_at":  "2024-03-01T10:00:00Z",
+			"service":     service,
+			"integration": Object{"id": IntegrationID, "type": "generic_events_api_inbound_integration_reference", "summary": "Datadog"},
+			"incident":    Object{"id": IncidentID, "type": "incident_reference"},
+			"body": Object{
+				"type":        "alert_body",
+				"cef_details": Object{"severity": "critical", "source_component": "api-gateway", "summary": "API latency above threshold"},
+			},
+		},
+	)
+	s.Seed("/incidents/"+SecondIncidentID+"/alerts",
+		Object{
+			"id":          SecondAlertID,
+			"type":        "alert",
+			"summary":     "Disk usage above threshold",
+			"status":      "triggered",
+			"severity":    "warning",
+			"alert_key":   "disk-2",
+			"suppressed":  true,
+			"created_at":  "2024-03-02T10:00:00Z",
+			"service":     service,
+			"integration": Object{"id": IntegrationID, "type": "generic_events_api_inbound_integration_reference", "summary": "Datadog"},
+			"incident":    Object{"id": SecondIncidentID, "type": "incident_reference"},
+			"body":        Object{"type": "alert_body", "cef_details": Object{"severity": "warning"}},
+		},
+	)
+
+	// The custom fields and their values are listed in one response, and not
+	// under the name of their path
+	s.Seed("/incidents/custom_fields",
+		Object{"id": "PFIELD1", "type": "field", "name": "customer_impact", "display_name": "Customer impact", "description": "How customers were affected.", "data_type": "string", "field_type": "single_value_fixed"},
+		Object{"id": "PFIELD2", "type": "field", "name": "impacted_users", "display_name": "Impacted users", "data_type": "integer", "field_type": "single_value"},
+		Object{"id": "PFIELD3", "type": "field", "name": "root_cause_confirmed", "display_name": "Root cause confirmed", "data_type": "boolean", "field_type": "single_value"},
+		Object{"id": "PFIELD4", "type": "field", "name": "detected_at", "display_name": "Detected at", "data_type": "datetime", "field_type": "single_value"},
+		Object{"id": "PFIELD5", "type": "field", "name": "affected_regions", "display_name": "Affected regions", "data_type": "string", "field_type": "multi_value"},
+		Object{"id": "PFIELD6", "type": "field", "name": "status", "display_name": "Status", "data_type": "string", "field_type": "single_value"},
+	)
+	s.Unpaginated("/incidents/custom_fields")
+	s.Named("/incidents/custom_fields", "fields")
+	s.Seed("/incidents/"+IncidentID+"/custom_fields/values",
+		Object{"id": "PFIELD1", "type": "field_value", "name": "customer_impact", "value": "degraded"},
+		Object{"id": "PFIELD2", "type": "field_value", "name": "impacted_users", "value": 1200},
+		Object{"id": "PFIELD3", "type": "field_value", "name": "root_cause_confirmed", "value": false},
+		Object{"id": "PFIELD4", "type": "field_value", "name": "detected_at", "value": "2024-03-01T09:55:00Z"},
+		Object{"id": "PFIELD5", "type": "field_value", "name": "affected_regions", "value": []string{"eu-west-1", "us-east-1"}},
+		Object{"id": "PFIELD6", "type": "field_value", "name": "status", "value": "investigating"},
+	)
+	s.Seed("/incidents/"+SecondIncidentID+"/custom_fields/values",
+		Object{"id": "PFIELD1", "type": "field_value", "name": "customer_impact", "value": nil},
+	)
+	for _, id := range []string{IncidentID, SecondIncidentID} {
+		s.Unpaginated("/incidents/" + id + "/custom_fields/values")
+		s.Named("/incidents/"+id+"/custom_fields/values", "custom_fields")
+	}
+
+	s.Seed("/oncalls",
+		Object{"user": user, "schedule": schedule, "escalation_policy": escalationPolicy, "escalation_level": 1, "start": "2024-03-01T00:00:00Z", "end": "2024-03-08T00:00:00Z"},
+	)
+
+	s.Seed("/priorities",
+		Object{"id": PriorityID, "type": "priority", "name": "P1", "summary": "P1", "description": "Critical"},
+	)
+
+	s.Seed("/rulesets",
+		Object{"id": RulesetID, "name": "Checkout Events", "type": "global", "routing_keys": []string{"R0123456789"}, "team": Object{"id": TeamID, "type": "team_reference"}},
+	)
+	s.Seed("/rulesets/"+RulesetID+"/rules",
+		Object{"id": RulesetRuleID, "position": 0, "disabled": false, "ruleset": Object{"id": RulesetID, "type": "ruleset_reference"}},
+	)
+
+	s.Seed("/tags",
+		Object{"id": TagID, "type": "tag", "label": "production", "summary": "production"},
+	)
+
+	s.Seed("/vendors",
+		Object{"id": VendorID, "type": "vendor", "name": "Datadog", "summary": "Datadog", "description": "Monitoring"},
+	)
+}
+
+// webURL returns the web app URL of a resource in the seeded account
+func webURL(resource string, id string) string {
+	return "https://" + Subdomain + ".pagerduty.com/" + resource + "/" + id
+}
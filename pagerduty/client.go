@@ -0,0 +1,74 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// defaultAPIURL is the REST API endpoint of the US service region, used when
+// no api_url is configured
+const defaultAPIURL = "https://api.pagerduty.com"
+
+// pagerDutyClient is the go-pagerduty client, along with what is needed to
+// call REST API endpoints directly. This is used where the client has no
+// method to fetch a single page, or doesn't support the endpoint at all.
+type pagerDutyClient struct {
+	*pagerduty.Client
+
+	apiEndpoint string
+
+	// authToken is empty for OAuth, where the transport authorizes requests
+	authToken string
+}
+
+// getJSON calls the REST API endpoint at path and decodes the JSON response
+// into v. Error responses are returned as a pagerduty.APIError, the same as
+// for the client's own methods.
+func (c *pagerDutyClient) getJSON(ctx context.Context, path string, query url.Values, v interface{}) error {
+	endpoint := c.apiEndpoint + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.pagerduty+json;version=2")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "go-pagerduty/"+pagerduty.Version)
+	if c.authToken != "" {
+		req.Header.Set("Authorization", "Token token="+c.authToken)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error calling the API endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// The error object is optional, so a body that fails to decode still
+		// results in an APIError with the status code
+		var apiErr pagerduty.APIError
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		apiErr.StatusCode = resp.StatusCode
+		return apiErr
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// listQuery returns the query parameters to fetch the page of an offset based
+// list endpoint
+func listQuery(req pageRequest) url.Values {
+	query := url.Values{}
+	query.Set("limit", fmt.Sprint(req.Limit))
+	query.Set("offset", fmt.Sprint(req.Offset))
+	return query
+}
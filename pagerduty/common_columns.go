@@ -0,0 +1,112 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// commonColumns adds the columns that identify the account to every table, so
+// that rows from several accounts can be told apart in an aggregator
+func commonColumns(c []*plugin.Column) []*plugin.Column {
+	return append(c, []*plugin.Column{
+		{
+			Name:        "subdomain",
+			Description: "The subdomain of the PagerDuty account, e.g. acme for acme.pagerduty.com.",
+			Type:        proto.ColumnType_STRING,
+			Hydrate:     getPagerDutySubdomain,
+			Transform:   transform.FromValue(),
+		},
+	}...)
+}
+
+// pagerDutyAccount identifies the account behind the connection's credentials.
+// Subdomain is empty if the credentials can't list users.
+type pagerDutyAccount struct {
+	Subdomain string
+}
+
+// getPagerDutyAccountMemoized looks up the account once per connection
+var getPagerDutyAccountMemoized = plugin.HydrateFunc(getPagerDutyAccountUncached).Memoize()
+
+func getPagerDutySubdomain(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	account, err := getPagerDutyAccountMemoized(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+	if account.(*pagerDutyAccount).Subdomain == "" {
+		return nil, nil
+	}
+	return account.(*pagerDutyAccount).Subdomain, nil
+}
+
+// getPagerDutyAccountUncached identifies the account from the web app URL of
+// one of its users. Unlike /users/me, this works with account level tokens as
+// well as user tokens.
+func getPagerDutyAccountUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	client, err := getSessionConfig(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty.getPagerDutyAccountUncached", "connection_error", err)
+		return nil, err
+	}
+
+	listUsers := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+		req := pagerduty.ListUsersOptions{}
+		req.APIListObject.Limit = 1
+		return client.ListUsersWithContext(ctx, req)
+	}
+
+	// Connection key columns are looked up outside of any table, which
+	// RetryHydrate relies on
+	var listResponse interface{}
+	if d.Table != nil {
+		listResponse, err = plugin.RetryHydrate(ctx, d, h, listUsers, retryConfig(d))
+	} else {
+		listResponse, err = listUsers(ctx, d, h)
+	}
+	// Without access to users, e.g. for an OAuth app without the users.read
+	// scope, the account is unknown, which mustn't fail the rows of every
+	// other table
+	if isAccessDeniedError(err) {
+		plugin.Logger(ctx).Warn("pagerduty.getPagerDutyAccountUncached", "access_error", err)
+		return &pagerDutyAccount{}, nil
+	}
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty.getPagerDutyAccountUncached", "query_error", err)
+		return nil, err
+	}
+
+	users := listResponse.(*pagerduty.ListUsersResponse).Users
+	if len(users) == 0 || users[0].HTMLURL == "" {
+		return nil, fmt.Errorf("unable to identify the PagerDuty account: no user has a web app URL")
+	}
+
+	subdomain, err := subdomainFromURL(users[0].HTMLURL)
+	if err != nil {
+		return nil, err
+	}
+	return &pagerDutyAccount{Subdomain: subdomain}, nil
+}
+
+// subdomainFromURL returns the account subdomain of a web app URL, e.g. acme
+// for https://acme.pagerduty.com/users/PXPGF42 or
+// https://acme.eu.pagerduty.com/users/PXPGF42
+func subdomainFromURL(webURL string) (string, error) {
+	u, err := url.Parse(webURL)
+	if err != nil {
+		return "", fmt.Errorf("unable to identify the PagerDuty account from %q: %v", webURL, err)
+	}
+	host := u.Hostname()
+	subdomain, _, found := strings.Cut(host, ".")
+	if !found || subdomain == "" {
+		return "", fmt.Errorf("unable to identify the PagerDuty account from %q", webURL)
+	}
+	return subdomain, nil
+}
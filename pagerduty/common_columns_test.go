@@ -0,0 +1,111 @@
+package pagerduty
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-pagerduty/internal/fakeserver"
+)
+
+func TestSubdomainFromURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+		err  bool
+	}{
+		{url: "https://acme.pagerduty.com/users/PXPGF42", want: "acme"},
+		{url: "https://acme.eu.pagerduty.com/users/PXPGF42", want: "acme"},
+		{url: "https://localhost/users/PXPGF42", err: true},
+		{url: "", err: true},
+	}
+
+	for _, test := range tests {
+		got, err := subdomainFromURL(test.url)
+		if test.err {
+			if err == nil {
+				t.Errorf("subdomainFromURL(%q) = %q, want an error", test.url, got)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("subdomainFromURL(%q) = %q, %v, want %q", test.url, got, err, test.want)
+		}
+	}
+}
+
+func TestSubdomainColumn(t *testing.T) {
+	server := newTestServer(t)
+
+	opts := map[string][]queryOption{
+		"pagerduty_incident_alert":          {withFixtureIncidents()},
+		"pagerduty_incident_log":            {withQual("incident_id", "=", fakeserver.IncidentID)},
+		"pagerduty_incident_note":           {withFixtureIncidents()},
+		"pagerduty_incident_state_interval": {withFixtureIncidents()},
+	}
+	for tableName := range tableDefinitions(testContext(), nil) {
+		rows, err := queryRows(t, server, tableName, append(opts[tableName], withColumns("subdomain"))...)
+		if err != nil {
+			t.Fatalf("query of %s failed: %v", tableName, err)
+		}
+		if len(rows) == 0 {
+			t.Errorf("got no rows of %s", tableName)
+		}
+		for _, row := range rows {
+			if row["subdomain"] != fakeserver.Subdomain {
+				t.Errorf("got subdomain %v for %s, want %s", row["subdomain"], tableName, fakeserver.Subdomain)
+			}
+		}
+	}
+}
+
+func TestSubdomainColumnWithoutAccessToUsers(t *testing.T) {
+	for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden} {
+		t.Run(strconv.Itoa(status), func(t *testing.T) {
+			server := newTestServer(t)
+			server.Fail("/users", status, 10)
+
+			rows, err := queryRows(t, server, "pagerduty_incident", withColumns("id", "subdomain"))
+			if err != nil {
+				t.Fatalf("query failed: %v", err)
+			}
+			if len(rows) < 2 {
+				t.Fatalf("got %d rows, want several", len(rows))
+			}
+			for _, row := range rows {
+				if row["subdomain"] != nil {
+					t.Errorf("got subdomain %v, want NULL", row["subdomain"])
+				}
+			}
+
+			// The account is only looked up once
+			if got := len(server.Requests("/users")); got != 1 {
+				t.Errorf("got %d requests for users, want 1", got)
+			}
+		})
+	}
+}
+
+func TestSubdomainConnectionKeyColumn(t *testing.T) {
+	server := newTestServer(t)
+
+	// The connection is only queried for its own subdomain
+	rows, err := queryRows(t, server, "pagerduty_team", withQual("subdomain", "=", fakeserver.Subdomain))
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Errorf("got %d rows for the connection's subdomain, want 1", len(rows))
+	}
+
+	rows, err = queryRows(t, server, "pagerduty_team", withQual("subdomain", "=", "other"))
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if len(rows) != 0 {
+		t.Errorf("got %d rows for another subdomain, want none", len(rows))
+	}
+	if got := len(server.Requests("/teams")); got != 1 {
+		t.Errorf("got %d requests for teams, want 1", got)
+	}
+}
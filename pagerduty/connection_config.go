@@ -1,17 +1,47 @@
 package pagerduty
 
 import (
+	"context"
+	"fmt"
+	"sync"
+
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
 
 type pagerDutyConfig struct {
-	Token *string `hcl:"token"`
+	Token         *string  `hcl:"token"`
+	TokenFile     *string  `hcl:"token_file"`
+	TokenCommand  *string  `hcl:"token_command"`
+	ClientID      *string  `hcl:"client_id"`
+	ClientSecret  *string  `hcl:"client_secret"`
+	Scopes        []string `hcl:"scopes,optional"`
+	OAuthTokenURL *string  `hcl:"oauth_token_url"`
+	APIURL        *string  `hcl:"api_url"`
+	EventsURL     *string  `hcl:"events_url"`
+
+	MaxRequestsPerMinute *int `hcl:"max_requests_per_minute"`
+	MaxRetries           *int `hcl:"max_retries"`
+	MinRetryDelay        *int `hcl:"min_retry_delay"`
+	MaxRetryDelay        *int `hcl:"max_retry_delay"`
+
+	IgnoreErrorCodes []string `hcl:"ignore_error_codes,optional"`
+
+	MaxConcurrency *int `hcl:"max_concurrency"`
+
+	RecordDir *string `hcl:"record_dir"`
+	ReplayDir *string `hcl:"replay_dir"`
+
+	TeamIDs []string `hcl:"team_ids,optional"`
 }
 
 func ConfigInstance() interface{} {
 	return &pagerDutyConfig{}
 }
 
+// defaultMaxConcurrency is the number of API requests a single query may make
+// in parallel, e.g. for each window of a long incident time range
+const defaultMaxConcurrency = 5
+
 // GetConfig :: retrieve and cast connection config from query data
 func GetConfig(connection *plugin.Connection) pagerDutyConfig {
 	if connection == nil || connection.Config == nil {
@@ -20,3 +50,44 @@ func GetConfig(connection *plugin.Connection) pagerDutyConfig {
 	config, _ := connection.Config.(pagerDutyConfig)
 	return config
 }
+
+// maxConcurrency returns the max_concurrency setting of the connection
+func maxConcurrency(d *plugin.QueryData) int {
+	config := GetConfig(d.Connection)
+	if config.MaxConcurrency != nil && *config.MaxConcurrency > 0 {
+		return *config.MaxConcurrency
+	}
+	return defaultMaxConcurrency
+}
+
+// connectionSemaphores hold the slots of the lists each connection runs in
+// parallel. The SDK runs the list call of each value of an IN qual in a
+// goroutine of its own, so the slots must be shared by those calls rather
+// than held by each of them.
+var (
+	connectionSemaphoresMu sync.Mutex
+	connectionSemaphores   = map[string]chan struct{}{}
+)
+
+// acquireConnectionSlot waits until fewer than max_concurrency lists of the
+// connection are running, and returns the function that releases the slot
+func acquireConnectionSlot(ctx context.Context, d *plugin.QueryData) (func(), error) {
+	size := maxConcurrency(d)
+	// A changed max_concurrency takes effect in a new set of slots
+	key := fmt.Sprintf("%s/%d", d.Connection.Name, size)
+
+	connectionSemaphoresMu.Lock()
+	sem, ok := connectionSemaphores[key]
+	if !ok {
+		sem = make(chan struct{}, size)
+		connectionSemaphores[key] = sem
+	}
+	connectionSemaphoresMu.Unlock()
+
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
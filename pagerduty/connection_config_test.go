@@ -0,0 +1,162 @@
+package pagerduty
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-pagerduty/internal/fakeserver"
+)
+
+func withTeamIDs(teamIDs ...string) queryOption {
+	return withConfig(func(config *pagerDutyConfig) {
+		config.TeamIDs = teamIDs
+	})
+}
+
+func TestTeamScopedLists(t *testing.T) {
+	tables := []struct {
+		table string
+		opts  []queryOption
+	}{
+		{table: "pagerduty_escalation_policy"},
+		{table: "pagerduty_incident"},
+		{table: "pagerduty_incident_alert", opts: []queryOption{withFixtureIncidents()}},
+		{table: "pagerduty_incident_alert", opts: []queryOption{withQual("incident_id", "=", fakeserver.IncidentID)}},
+		{table: "pagerduty_incident_note", opts: []queryOption{withFixtureIncidents()}},
+		{table: "pagerduty_incident_log", opts: []queryOption{withQual("incident_id", "=", fakeserver.IncidentID)}},
+		{table: "pagerduty_incident_state_interval", opts: []queryOption{withFixtureIncidents()}},
+		{table: "pagerduty_log_entry"},
+		{table: "pagerduty_on_call"},
+		{table: "pagerduty_ruleset"},
+		{table: "pagerduty_ruleset_rule"},
+		{table: "pagerduty_schedule"},
+		{table: "pagerduty_schedule_user"},
+		{table: "pagerduty_service"},
+		{table: "pagerduty_service_integration"},
+		{table: "pagerduty_team"},
+		{table: "pagerduty_user"},
+	}
+
+	for _, test := range tables {
+		t.Run(test.table, func(t *testing.T) {
+			server := newTestServer(t)
+
+			rows, err := queryRows(t, server, test.table, append(test.opts, withTeamIDs(fakeserver.TeamID))...)
+			if err != nil {
+				t.Fatalf("list failed: %v", err)
+			}
+			if len(rows) == 0 {
+				t.Errorf("got no rows for the seeded team")
+			}
+
+			rows, err = queryRows(t, server, test.table, append(test.opts, withTeamIDs("POTHER1"))...)
+			if err != nil {
+				t.Fatalf("list failed: %v", err)
+			}
+			if len(rows) != 0 {
+				t.Errorf("got %d rows for another team, want none", len(rows))
+			}
+		})
+	}
+}
+
+func TestTeamScopedListsUseTheAPIFilter(t *testing.T) {
+	tests := []struct {
+		table string
+		path  string
+	}{
+		{table: "pagerduty_escalation_policy", path: "/escalation_policies"},
+		{table: "pagerduty_incident", path: "/incidents"},
+		{table: "pagerduty_log_entry", path: "/log_entries"},
+		{table: "pagerduty_service", path: "/services"},
+		{table: "pagerduty_user", path: "/users"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.table, func(t *testing.T) {
+			server := newTestServer(t)
+
+			if _, err := queryRows(t, server, test.table, withTeamIDs(fakeserver.TeamID)); err != nil {
+				t.Fatalf("list failed: %v", err)
+			}
+			requests := server.Requests(test.path)
+			if len(requests) == 0 {
+				t.Fatalf("no requests to %s", test.path)
+			}
+			if got := requests[0].Query["team_ids[]"]; len(got) != 1 || got[0] != fakeserver.TeamID {
+				t.Errorf("got team_ids[]=%v, want %s", got, fakeserver.TeamID)
+			}
+		})
+	}
+}
+
+func TestTeamScopedListsFetchFullPages(t *testing.T) {
+	tests := []struct {
+		table string
+		path  string
+	}{
+		{table: "pagerduty_ruleset", path: "/rulesets"},
+		{table: "pagerduty_schedule", path: "/schedules"},
+		{table: "pagerduty_team", path: "/teams"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.table, func(t *testing.T) {
+			server := newTestServer(t)
+
+			// The LIMIT counts the rows of the teams, which are filtered after
+			// they are fetched
+			if _, err := queryRows(t, server, test.table, withTeamIDs(fakeserver.TeamID), withLimit(1)); err != nil {
+				t.Fatalf("list failed: %v", err)
+			}
+			requests := server.Requests(test.path)
+			if len(requests) == 0 {
+				t.Fatalf("no requests to %s", test.path)
+			}
+			if got := requests[0].Query.Get("limit"); got != "100" {
+				t.Errorf("got limit=%s, want 100", got)
+			}
+		})
+	}
+}
+
+func TestTeamScopedGets(t *testing.T) {
+	tests := []struct {
+		table string
+		id    string
+		opts  []queryOption
+	}{
+		{table: "pagerduty_escalation_policy", id: fakeserver.EscalationPolicyID},
+		{table: "pagerduty_incident", id: fakeserver.IncidentID},
+		{table: "pagerduty_incident_alert", id: fakeserver.AlertID, opts: []queryOption{withQual("incident_id", "=", fakeserver.IncidentID)}},
+		{table: "pagerduty_log_entry", id: fakeserver.LogEntryID},
+		{table: "pagerduty_ruleset", id: fakeserver.RulesetID},
+		{table: "pagerduty_ruleset_rule", id: fakeserver.RulesetRuleID, opts: []queryOption{withQual("ruleset_id", "=", fakeserver.RulesetID)}},
+		{table: "pagerduty_schedule", id: fakeserver.ScheduleID},
+		{table: "pagerduty_service", id: fakeserver.ServiceID},
+		{table: "pagerduty_service_integration", id: fakeserver.IntegrationID, opts: []queryOption{withQual("service_id", "=", fakeserver.ServiceID)}},
+		{table: "pagerduty_team", id: fakeserver.TeamID},
+		{table: "pagerduty_user", id: fakeserver.UserID},
+	}
+
+	for _, test := range tests {
+		t.Run(test.table, func(t *testing.T) {
+			server := newTestServer(t)
+
+			row, err := queryRow(t, server, test.table, append(test.opts, withQual("id", "=", test.id), withTeamIDs(fakeserver.TeamID))...)
+			if err != nil {
+				t.Fatalf("get failed: %v", err)
+			}
+			if row == nil {
+				t.Error("got no row for the seeded team")
+			}
+
+			row, err = queryRow(t, server, test.table, append(test.opts, withQual("id", "=", test.id), withTeamIDs("POTHER1"))...)
+			if err != nil {
+				t.Fatalf("get failed: %v", err)
+			}
+			if row != nil {
+				t.Errorf("got %v for another team, want no row", row)
+			}
+		})
+	}
+}
@@ -1,16 +1,182 @@
 package pagerduty
 
 import (
+	"context"
 	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
 
 	"github.com/PagerDuty/go-pagerduty"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"golang.org/x/oauth2"
 )
 
-func shouldRetryError(err error) bool {
-	var aerr pagerduty.APIError
+const (
+	defaultMaxRetries    = 10
+	defaultMinRetryDelay = 100   // milliseconds
+	defaultMaxRetryDelay = 30000 // milliseconds
+)
+
+// retryConfig returns the retry policy shared by every API call, using the
+// max_retries, min_retry_delay and max_retry_delay connection settings.
+//
+// Delays back off exponentially from min_retry_delay up to max_retry_delay.
+// Any Retry-After or ratelimit-reset response header is honoured by the
+// client transport, which holds back the retried request until then.
+func retryConfig(d *plugin.QueryData) *plugin.RetryConfig {
+	config := GetConfig(d.Connection)
+
+	maxRetries := defaultMaxRetries
+	if config.MaxRetries != nil {
+		maxRetries = *config.MaxRetries
+	}
+	minDelay := defaultMinRetryDelay
+	if config.MinRetryDelay != nil && *config.MinRetryDelay > 0 {
+		minDelay = *config.MinRetryDelay
+	}
+	maxDelay := defaultMaxRetryDelay
+	if config.MaxRetryDelay != nil && *config.MaxRetryDelay > 0 {
+		maxDelay = *config.MaxRetryDelay
+	}
+	if maxDelay < minDelay {
+		maxDelay = minDelay
+	}
+
+	retryConfig := &plugin.RetryConfig{
+		ShouldRetryErrorFunc: shouldRetryError,
+		MaxAttempts:          int64(maxRetries),
+		BackoffAlgorithm:     "Exponential",
+		RetryInterval:        int64(minDelay),
+		CappedDuration:       int64(maxDelay),
+	}
+
+	// A MaxAttempts of zero means the SDK default, so disable retries by
+	// never classifying an error as retryable
+	if maxRetries <= 0 {
+		retryConfig.ShouldRetryErrorFunc = func(context.Context, *plugin.QueryData, *plugin.HydrateData, error) bool {
+			return false
+		}
+	}
+
+	return retryConfig
+}
 
+// shouldRetryError returns true for transient errors: rate limiting (429),
+// server errors (500, 502, 503 and 504) and network failures
+func shouldRetryError(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, err error) bool {
+	if isRetryableError(ctx, err) {
+		plugin.Logger(ctx).Debug("pagerduty.shouldRetryError", "retrying", err)
+		return true
+	}
+	return false
+}
+
+func isRetryableError(ctx context.Context, err error) bool {
+	// Never retry once the query has been cancelled
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var aerr pagerduty.APIError
 	if errors.As(err, &aerr) {
-		return aerr.RateLimited()
+		switch aerr.StatusCode {
+		case http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	return isNetworkError(err)
+}
+
+// transientErrorMessages identify transient network failures in the errors
+// the client flattens into a string
+var transientErrorMessages = []string{
+	"connection reset by peer",
+	"connection refused",
+	"unexpected EOF",
+	"i/o timeout",
+	"Client.Timeout exceeded",
+	"TLS handshake timeout",
+}
+
+// isNetworkError returns true for timeouts, connection resets and refusals,
+// and responses cut short. Other transport failures, such as TLS errors, a
+// bad api_url or OAuth credentials that are refused, fail the same way every
+// time.
+func isNetworkError(err error) bool {
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	// The client flattens transport errors into a string, so they can only be
+	// identified by their message
+	msg := err.Error()
+	if !strings.HasPrefix(msg, "Error calling the API endpoint:") || strings.Contains(msg, "oauth2:") {
+		return false
+	}
+	for _, transient := range transientErrorMessages {
+		if strings.Contains(msg, transient) {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldIgnoreError returns true for API errors matching the
+// ignore_error_codes connection setting, so that a list returns no rows and a
+// hydrate returns NULL columns instead of failing the whole query
+func shouldIgnoreError(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, err error) bool {
+	config := GetConfig(d.Connection)
+	if !isIgnorableError(err, config.IgnoreErrorCodes) {
+		return false
+	}
+
+	tableName := ""
+	if d.Table != nil {
+		tableName = d.Table.Name
+	}
+	plugin.Logger(ctx).Warn("pagerduty.shouldIgnoreError", "table", tableName, "ignored_error", err)
+	return true
+}
+
+// isIgnorableError returns true if the error is an API error whose HTTP status
+// code (e.g. "403") or PagerDuty error code (e.g. "2010") is in codes
+func isIgnorableError(err error, codes []string) bool {
+	if len(codes) == 0 {
+		return false
+	}
+
+	var aerr pagerduty.APIError
+	if !errors.As(err, &aerr) {
+		return false
+	}
+
+	for _, code := range codes {
+		code = strings.TrimSpace(code)
+		if code == strconv.Itoa(aerr.StatusCode) {
+			return true
+		}
+		if aerr.APIError.Valid && code == strconv.Itoa(aerr.APIError.ErrorObject.Code) {
+			return true
+		}
 	}
 	return false
 }
@@ -23,3 +189,14 @@ func isNotFoundError(err error) bool {
 	}
 	return false
 }
+
+// isAccessDeniedError returns true if the API refused the credentials, or they
+// aren't allowed to make the request, e.g. an OAuth app without the scope
+func isAccessDeniedError(err error) bool {
+	var aerr pagerduty.APIError
+
+	if errors.As(err, &aerr) {
+		return aerr.StatusCode == http.StatusUnauthorized || aerr.StatusCode == http.StatusForbidden
+	}
+	return false
+}
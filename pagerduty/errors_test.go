@@ -0,0 +1,188 @@
+package pagerduty
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"golang.org/x/oauth2"
+
+	"github.com/turbot/steampipe-plugin-pagerduty/internal/fakeserver"
+)
+
+func apiError(status int, code int) error {
+	return pagerduty.APIError{
+		StatusCode: status,
+		APIError: pagerduty.NullAPIErrorObject{
+			Valid:       code != 0,
+			ErrorObject: pagerduty.APIErrorObject{Code: code},
+		},
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "rate limited", err: apiError(429, 0), want: true},
+		{name: "server error", err: apiError(500, 0), want: true},
+		{name: "service unavailable", err: apiError(503, 0), want: true},
+		{name: "bad request", err: apiError(400, 2001), want: false},
+		{name: "forbidden", err: apiError(403, 2010), want: false},
+		{name: "not found", err: apiError(404, 2100), want: false},
+		{name: "unexpected EOF", err: fmt.Errorf("reading response: %w", io.ErrUnexpectedEOF), want: true},
+		{name: "client transport error", err: errors.New("Error calling the API endpoint: connection reset by peer"), want: true},
+		{name: "client timeout", err: errors.New("Error calling the API endpoint: Get \"https://api.pagerduty.com/users\": dial tcp: i/o timeout"), want: true},
+		{name: "client TLS error", err: errors.New("Error calling the API endpoint: Get \"https://api.pagerduty.com/users\": tls: failed to verify certificate: x509: certificate signed by unknown authority"), want: false},
+		{name: "client OAuth error", err: errors.New("Error calling the API endpoint: Get \"https://api.pagerduty.com/users\": oauth2: \"invalid_client\""), want: false},
+		{name: "timeout", err: &url.Error{Op: "Get", URL: "https://api.pagerduty.com/users", Err: timeoutError{}}, want: true},
+		{name: "connection refused", err: &url.Error{Op: "Get", URL: "https://api.pagerduty.com/users", Err: syscall.ECONNREFUSED}, want: true},
+		{name: "bad api_url", err: &url.Error{Op: "Get", URL: "ftp://api.pagerduty.com/users", Err: errors.New("unsupported protocol scheme \"ftp\"")}, want: false},
+		{name: "OAuth error", err: &url.Error{Op: "Get", URL: "https://api.pagerduty.com/users", Err: &oauth2.RetrieveError{ErrorCode: "invalid_client"}}, want: false},
+		{name: "other", err: errors.New("invalid configuration"), want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isRetryableError(context.Background(), test.err); got != test.want {
+				t.Errorf("isRetryableError(%v) = %v, want %v", test.err, got, test.want)
+			}
+		})
+	}
+}
+
+// timeoutError is a network error that timed out
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestRefusedOAuthCredentialsAreNotRetried(t *testing.T) {
+	server := newTestServer(t)
+	var tokenRequests int64
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&tokenRequests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"invalid_client","error_description":"Client authentication failed"}`))
+	}))
+	t.Cleanup(tokenServer.Close)
+
+	_, err := queryRows(t, server, "pagerduty_team", withColumns("id"), withConfig(func(config *pagerDutyConfig) {
+		clientID, clientSecret, tokenURL := "client", "wrong-secret", tokenServer.URL
+		config.Token = nil
+		config.ClientID = &clientID
+		config.ClientSecret = &clientSecret
+		config.OAuthTokenURL = &tokenURL
+	}))
+	if err == nil || !strings.Contains(err.Error(), "invalid_client") {
+		t.Fatalf("got %v, want the invalid_client error", err)
+	}
+	// One request when the plugin starts, for the custom fields, and one for
+	// the query
+	if got := atomic.LoadInt64(&tokenRequests); got != 2 {
+		t.Errorf("got %d token requests, want 2", got)
+	}
+}
+
+func TestTLSErrorsAreNotRetried(t *testing.T) {
+	server := newTestServer(t)
+	var connections int64
+	tlsServer := httptest.NewUnstartedServer(http.NotFoundHandler())
+	tlsServer.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt64(&connections, 1)
+		}
+	}
+	tlsServer.StartTLS()
+	t.Cleanup(tlsServer.Close)
+
+	// The client doesn't trust the server's certificate
+	_, err := queryRows(t, server, "pagerduty_team", withColumns("id"), withConfig(func(config *pagerDutyConfig) {
+		config.APIURL = &tlsServer.URL
+	}))
+	if err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Fatalf("got %v, want a certificate error", err)
+	}
+	// One connection when the plugin starts, for the custom fields, and one
+	// for the query
+	if got := atomic.LoadInt64(&connections); got != 2 {
+		t.Errorf("got %d connections, want 2", got)
+	}
+}
+
+func TestIsRetryableErrorAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if isRetryableError(ctx, apiError(429, 0)) {
+		t.Error("got a retryable error after the query was cancelled")
+	}
+}
+
+func TestIsIgnorableError(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		codes []string
+		want  bool
+	}{
+		{name: "no codes", err: apiError(403, 2010), codes: nil, want: false},
+		{name: "HTTP status", err: apiError(403, 2010), codes: []string{"403"}, want: true},
+		{name: "PagerDuty code", err: apiError(403, 2010), codes: []string{"2010"}, want: true},
+		{name: "padded code", err: apiError(403, 2010), codes: []string{" 2010 "}, want: true},
+		{name: "other status", err: apiError(500, 0), codes: []string{"403"}, want: false},
+		{name: "not an API error", err: errors.New("403"), codes: []string{"403"}, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isIgnorableError(test.err, test.codes); got != test.want {
+				t.Errorf("isIgnorableError(%v, %v) = %v, want %v", test.err, test.codes, got, test.want)
+			}
+		})
+	}
+}
+
+func TestReplayedRequestWithoutRecording(t *testing.T) {
+	client := &pagerDutyClient{
+		Client:      pagerduty.NewClient(""),
+		apiEndpoint: defaultAPIURL,
+	}
+	client.HTTPClient = &http.Client{Transport: &replayTransport{dir: t.TempDir()}}
+
+	errs := map[string]error{
+		"direct": client.getJSON(context.Background(), "/users", nil, &struct{}{}),
+	}
+	_, errs["client"] = client.ListIncidentNotesWithContext(context.Background(), fakeserver.IncidentID)
+
+	for name, err := range errs {
+		t.Run(name, func(t *testing.T) {
+			var aerr pagerduty.APIError
+			if !errors.As(err, &aerr) || aerr.StatusCode != http.StatusNotImplemented {
+				t.Fatalf("got %v, want a 501 API error", err)
+			}
+			if !strings.Contains(err.Error(), "no recorded response in replay_dir") {
+				t.Errorf("got %v, want the missing recording", err)
+			}
+
+			// A request that wasn't recorded never succeeds, so it isn't retried
+			if isRetryableError(context.Background(), err) {
+				t.Errorf("got a retryable error for a missing recording")
+			}
+		})
+	}
+}
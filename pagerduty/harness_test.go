@@ -0,0 +1,510 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/turbot/steampipe-plugin-pagerduty/internal/fakeserver"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/context_key"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestMain(m *testing.M) {
+	// The SDK logs to stderr and to the standard logger, which is too noisy
+	// for test output
+	os.Setenv("STEAMPIPE_LOG_LEVEL", "OFF")
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// testConnectionName is the name of the connection queries run against
+const testConnectionName = "pagerduty"
+
+// testQuery describes a query against a table. It is run through the SDK,
+// as Steampipe runs it, against one or more connections to fake servers.
+type testQuery struct {
+	config  pagerDutyConfig
+	quals   map[string]*proto.Quals
+	limit   *int64
+	columns []string
+}
+
+type queryOption func(q *testQuery)
+
+// withQual adds a qual on the column, e.g. withQual("status", "=", "resolved").
+// A []string value is a list, as for IN.
+func withQual(column string, operator string, value interface{}) queryOption {
+	return func(q *testQuery) {
+		if q.quals[column] == nil {
+			q.quals[column] = &proto.Quals{}
+		}
+		q.quals[column].Quals = append(q.quals[column].Quals, &proto.Qual{
+			FieldName: column,
+			Operator:  &proto.Qual_StringValue{StringValue: operator},
+			Value:     qualValue(value),
+		})
+	}
+}
+
+// withLimit sets the LIMIT of the query
+func withLimit(limit int64) queryOption {
+	return func(q *testQuery) {
+		q.limit = &limit
+	}
+}
+
+// withFixtureIncidents lists the tables listed per incident for the incidents
+// of the fixtures, which are older than the default window of these tables
+func withFixtureIncidents() queryOption {
+	return withQual("incident_created_at", ">=", timestamp("2024-01-01T00:00:00Z"))
+}
+
+// withColumns sets the columns the query selects
+func withColumns(columns ...string) queryOption {
+	return func(q *testQuery) {
+		q.columns = columns
+	}
+}
+
+// withConfig changes the connection config
+func withConfig(configure func(config *pagerDutyConfig)) queryOption {
+	return func(q *testQuery) {
+		configure(&q.config)
+	}
+}
+
+func qualValue(value interface{}) *proto.QualValue {
+	switch v := value.(type) {
+	case string:
+		return &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: v}}
+	case int64:
+		return &proto.QualValue{Value: &proto.QualValue_Int64Value{Int64Value: v}}
+	case int:
+		return &proto.QualValue{Value: &proto.QualValue_Int64Value{Int64Value: int64(v)}}
+	case bool:
+		return &proto.QualValue{Value: &proto.QualValue_BoolValue{BoolValue: v}}
+	case []string:
+		list := &proto.QualValueList{}
+		for _, s := range v {
+			list.Values = append(list.Values, qualValue(s))
+		}
+		return &proto.QualValue{Value: &proto.QualValue_ListValue{ListValue: list}}
+	case time.Time:
+		return &proto.QualValue{Value: &proto.QualValue_TimestampValue{TimestampValue: timestamppb.New(v)}}
+	}
+	panic("unsupported qual value type")
+}
+
+// testContext returns a context with the logger the plugin's functions expect
+func testContext() context.Context {
+	return context.WithValue(context.Background(), context_key.Logger, hclog.NewNullLogger())
+}
+
+// newTestServer starts a fake server seeded with the standard fixtures
+func newTestServer(t *testing.T) *fakeserver.Server {
+	t.Helper()
+	server := fakeserver.New()
+	t.Cleanup(server.Close)
+	server.SeedFixtures()
+	return server
+}
+
+// newTestQuery returns the query against a connection to the server
+func newTestQuery(server *fakeserver.Server, opts ...queryOption) *testQuery {
+	apiURL := server.URL
+	token := "test-token"
+	requestsPerMinute := 600000
+	minRetryDelay := 1
+	maxRetryDelay := 10
+	q := &testQuery{
+		config: pagerDutyConfig{
+			Token:                &token,
+			APIURL:               &apiURL,
+			MaxRequestsPerMinute: &requestsPerMinute,
+			MinRetryDelay:        &minRetryDelay,
+			MaxRetryDelay:        &maxRetryDelay,
+		},
+		quals: map[string]*proto.Quals{},
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+// configHCL returns the connection config as it is written in a .spc file
+func configHCL(config pagerDutyConfig) string {
+	var hcl strings.Builder
+	value := reflect.ValueOf(config)
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if field.IsNil() {
+			continue
+		}
+		if field.Kind() == reflect.Pointer {
+			field = field.Elem()
+		}
+		// JSON strings, numbers and lists are valid HCL
+		data, err := json.Marshal(field.Interface())
+		if err != nil {
+			panic(err)
+		}
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("hcl"), ",")
+		fmt.Fprintf(&hcl, "%s = %s\n", name, data)
+	}
+	return hcl.String()
+}
+
+// testPluginServer is the plugin server shared by the tests. Each test adds
+// its own connections to it: starting a server per test creates a query
+// cache per test, which uses too much memory.
+var (
+	testPluginServer     *grpc.PluginServer
+	testPluginServerOnce sync.Once
+	testConnectionID     int64
+)
+
+// testPlugin is the plugin server with the connections of a test
+type testPlugin struct {
+	server *grpc.PluginServer
+	// connections maps the connection names the test uses to the names of
+	// its connections in the server, which are unique across tests
+	connections map[string]string
+}
+
+// connection returns the name in the server of the test's connection
+func (p *testPlugin) connection(name string) string {
+	if unique, ok := p.connections[name]; ok {
+		return unique
+	}
+	return name
+}
+
+// startPlugin adds the connections to the plugin, as Steampipe does before
+// running queries. They are removed when the test ends.
+func startPlugin(t *testing.T, connections ...*proto.ConnectionConfig) *testPlugin {
+	t.Helper()
+
+	testPluginServerOnce.Do(func() {
+		testPluginServer = plugin.Server(&plugin.ServeOpts{PluginFunc: Plugin})
+		if _, err := testPluginServer.SetAllConnectionConfigs(&proto.SetAllConnectionConfigsRequest{MaxCacheSizeMb: -1}); err != nil {
+			panic(err)
+		}
+		// Every query is run against the API
+		if _, err := testPluginServer.SetCacheOptions(&proto.SetCacheOptionsRequest{Enabled: false}); err != nil {
+			panic(err)
+		}
+	})
+
+	p := &testPlugin{server: testPluginServer, connections: map[string]string{}}
+	for _, connection := range connections {
+		p.connections[connection.Connection] = fmt.Sprintf("%s_%d", connection.Connection, atomic.AddInt64(&testConnectionID, 1))
+	}
+	for _, connection := range connections {
+		connection.Connection = p.connection(connection.Connection)
+		for i, child := range connection.ChildConnections {
+			connection.ChildConnections[i] = p.connection(child)
+		}
+		connection.Plugin = pluginName
+		connection.PluginShortName = "pagerduty"
+	}
+	res, err := p.server.UpdateConnectionConfigs(&proto.UpdateConnectionConfigsRequest{Added: connections})
+	if err != nil {
+		t.Fatalf("failed to add the connections: %v", err)
+	}
+	for name, failure := range res.FailedConnections {
+		t.Fatalf("failed to set the config of connection %s: %s", name, failure)
+	}
+	t.Cleanup(func() {
+		p.server.UpdateConnectionConfigs(&proto.UpdateConnectionConfigsRequest{Deleted: connections})
+	})
+	return p
+}
+
+// startTestPlugin starts the plugin with a connection to the server
+func startTestPlugin(t *testing.T, server *fakeserver.Server, opts ...queryOption) *testPlugin {
+	t.Helper()
+
+	q := newTestQuery(server, opts...)
+	return startPlugin(t, &proto.ConnectionConfig{Connection: testConnectionName, Config: configHCL(q.config)})
+}
+
+// tableSchema returns the schema of the table for the connection
+func tableSchema(t *testing.T, p *testPlugin, connectionName string, tableName string) *proto.TableSchema {
+	t.Helper()
+
+	res, err := p.server.GetSchema(&proto.GetSchemaRequest{Connection: p.connection(connectionName)})
+	if err != nil {
+		t.Fatalf("failed to get the schema of %s: %v", connectionName, err)
+	}
+	schema, ok := res.Schema.Schema[tableName]
+	if !ok {
+		t.Fatalf("connection %s has no table %s", connectionName, tableName)
+	}
+	return schema
+}
+
+// rowStream collects the rows of a query, in place of the gRPC stream to
+// Steampipe
+type rowStream struct {
+	proto.WrapperPlugin_ExecuteServer
+	ctx  context.Context
+	rows []*proto.Row
+}
+
+func (s *rowStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *rowStream) Send(res *proto.ExecuteResponse) error {
+	s.rows = append(s.rows, res.Row)
+	return nil
+}
+
+var testCallID int64
+
+// executeQuery runs the query against the table of the connection, which may
+// be an aggregator of the children connections. It returns the rows that
+// match its quals, as Postgres only keeps those of the rows the plugin
+// returns.
+func executeQuery(t *testing.T, p *testPlugin, connectionName string, children []string, tableName string, q *testQuery) ([]map[string]interface{}, error) {
+	t.Helper()
+
+	columns := slices.Clone(q.columns)
+	if columns == nil {
+		for _, column := range tableSchema(t, p, connectionName, tableName).Columns {
+			columns = append(columns, column.Name)
+		}
+	}
+	// Postgres also selects the columns of the quals, to check them
+	for column := range q.quals {
+		if !slices.Contains(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+	var limit *proto.NullableInt
+	if q.limit != nil {
+		limit = &proto.NullableInt{Value: *q.limit}
+	}
+	if children == nil {
+		children = []string{connectionName}
+	}
+	connections := map[string]*proto.ExecuteConnectionData{}
+	for _, child := range children {
+		connections[p.connection(child)] = &proto.ExecuteConnectionData{Limit: limit}
+	}
+
+	stream := &rowStream{ctx: context.Background()}
+	err := p.server.Execute(&proto.ExecuteRequest{
+		Table:                 tableName,
+		QueryContext:          &proto.QueryContext{Columns: columns, Quals: q.quals, Limit: limit},
+		Connection:            p.connection(connectionName),
+		CallId:                fmt.Sprintf("test-%d", atomic.AddInt64(&testCallID, 1)),
+		ExecuteConnectionData: connections,
+	}, stream)
+
+	var rows []map[string]interface{}
+	for _, r := range stream.rows {
+		row := map[string]interface{}{}
+		for name, column := range r.Columns {
+			row[name] = protoColumnValue(t, column)
+		}
+		if rowMatchesQuals(row, q.quals) {
+			rows = append(rows, row)
+		}
+	}
+	return rows, err
+}
+
+// queryRows runs the query against the table of a connection to the server,
+// and returns the rows as column values
+func queryRows(t *testing.T, server *fakeserver.Server, tableName string, opts ...queryOption) ([]map[string]interface{}, error) {
+	t.Helper()
+
+	p := startTestPlugin(t, server, opts...)
+	return executeQuery(t, p, testConnectionName, nil, tableName, newTestQuery(server, opts...))
+}
+
+// queryRow runs a query that returns at most one row, such as a get by ID,
+// and returns the row or nil
+func queryRow(t *testing.T, server *fakeserver.Server, tableName string, opts ...queryOption) (map[string]interface{}, error) {
+	t.Helper()
+
+	rows, err := queryRows(t, server, tableName, opts...)
+	if len(rows) > 1 {
+		t.Fatalf("got %d rows of %s, want at most 1", len(rows), tableName)
+	}
+	if len(rows) == 0 {
+		return nil, err
+	}
+	return rows[0], err
+}
+
+// findRow returns the row whose column has the value
+func findRow(t *testing.T, rows []map[string]interface{}, column string, value interface{}) map[string]interface{} {
+	t.Helper()
+
+	for _, row := range rows {
+		if row[column] == value {
+			return row
+		}
+	}
+	t.Fatalf("got no row with %s = %v", column, value)
+	return nil
+}
+
+// protoColumnValue returns the value of a column of a returned row. JSON is
+// decoded, and timestamps are returned as time.Time.
+func protoColumnValue(t *testing.T, column *proto.Column) interface{} {
+	t.Helper()
+
+	switch v := column.Value.(type) {
+	case *proto.Column_NullValue:
+		return nil
+	case *proto.Column_BoolValue:
+		return v.BoolValue
+	case *proto.Column_IntValue:
+		return v.IntValue
+	case *proto.Column_DoubleValue:
+		return v.DoubleValue
+	case *proto.Column_StringValue:
+		return v.StringValue
+	case *proto.Column_TimestampValue:
+		return v.TimestampValue.AsTime()
+	case *proto.Column_JsonValue:
+		var decoded interface{}
+		if err := json.Unmarshal(v.JsonValue, &decoded); err != nil {
+			t.Fatalf("failed to decode JSON column value %s: %v", v.JsonValue, err)
+		}
+		return decoded
+	case *proto.Column_IpAddrValue:
+		return v.IpAddrValue
+	case *proto.Column_CidrRangeValue:
+		return v.CidrRangeValue
+	case *proto.Column_LtreeValue:
+		return v.LtreeValue
+	}
+	t.Fatalf("unsupported column value %T", column.Value)
+	return nil
+}
+
+// rowMatchesQuals returns true if the row matches every qual, as Postgres
+// checks the rows a foreign table returns
+func rowMatchesQuals(row map[string]interface{}, quals map[string]*proto.Quals) bool {
+	for column, columnQuals := range quals {
+		for _, q := range columnQuals.Quals {
+			if !valueMatchesQual(row[column], q.GetStringValue(), q.Value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// valueMatchesQual returns true if the value matches the operator and qual
+// value. A list value matches any of its values for = (IN), and none for <>
+// (NOT IN). NULL doesn't match any qual.
+func valueMatchesQual(value interface{}, operator string, qualValue *proto.QualValue) bool {
+	if value == nil {
+		return false
+	}
+	if list := qualValue.GetListValue(); list != nil {
+		for _, v := range list.Values {
+			if valueMatchesQual(value, "=", v) {
+				return operator == "="
+			}
+		}
+		return operator == "<>"
+	}
+
+	var cmp int
+	switch v := value.(type) {
+	case string:
+		cmp = strings.Compare(v, qualValue.GetStringValue())
+	case int64:
+		cmp = compareFloat(float64(v), float64(qualValue.GetInt64Value()))
+	case float64:
+		want := qualValue.GetDoubleValue()
+		if _, ok := qualValue.Value.(*proto.QualValue_Int64Value); ok {
+			want = float64(qualValue.GetInt64Value())
+		}
+		cmp = compareFloat(v, want)
+	case bool:
+		if v != qualValue.GetBoolValue() {
+			cmp = 1
+		}
+	case time.Time:
+		cmp = v.Compare(qualValue.GetTimestampValue().AsTime())
+	default:
+		// Quals on JSON columns aren't checked
+		return true
+	}
+
+	switch operator {
+	case "=":
+		return cmp == 0
+	case "<>":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return true
+}
+
+func compareFloat(a float64, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// timestamp returns the time of an RFC 3339 timestamp, as TIMESTAMP columns
+// are returned
+func timestamp(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// sortRows sorts the rows by the column, as rows may be returned in any order
+// when they are listed in parallel
+func sortRows(rows []map[string]interface{}, column string) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		switch a := rows[i][column].(type) {
+		case string:
+			return a < rows[j][column].(string)
+		case int64:
+			return a < rows[j][column].(int64)
+		case time.Time:
+			return a.Before(rows[j][column].(time.Time))
+		}
+		return false
+	})
+}
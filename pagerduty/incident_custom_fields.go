@@ -0,0 +1,149 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// customFieldsTimeout bounds how long loading the custom field definitions may
+// hold up the plugin's start
+const customFieldsTimeout = 30 * time.Second
+
+// incidentCustomField is the definition of an incident custom field of the
+// account, e.g. customer_impact
+type incidentCustomField struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Description string `json:"description"`
+	DataType    string `json:"data_type"`
+	FieldType   string `json:"field_type"`
+}
+
+// incidentCustomFieldValue is the value of a custom field of an incident
+type incidentCustomFieldValue struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// listIncidentCustomFields returns the incident custom fields defined in the
+// account of the connection. The timeout covers getting the token too, e.g.
+// from token_command.
+func listIncidentCustomFields(ctx context.Context, connection *plugin.Connection) ([]incidentCustomField, error) {
+	ctx, cancel := context.WithTimeout(ctx, customFieldsTimeout)
+	defer cancel()
+
+	client, err := newPagerDutyClient(ctx, connection)
+	if err != nil {
+		return nil, err
+	}
+
+	// The custom field definitions aren't paginated
+	var resp struct {
+		Fields []incidentCustomField `json:"fields"`
+	}
+	if err := client.getJSON(ctx, "/incidents/custom_fields", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Fields, nil
+}
+
+// incidentCustomFieldColumns adds a column for each custom field to the
+// columns of the incident table. Fields named like a column of the table are
+// left out.
+func incidentCustomFieldColumns(ctx context.Context, customFields []incidentCustomField, c []*plugin.Column) []*plugin.Column {
+	names := map[string]bool{}
+	for _, column := range commonColumns(c) {
+		names[column.Name] = true
+	}
+
+	for _, field := range customFields {
+		if field.Name == "" || names[field.Name] || plugin.IsReservedColumnName(field.Name) {
+			plugin.Logger(ctx).Warn("pagerduty_incident.incidentCustomFieldColumns", "skipped_custom_field", field.Name)
+			continue
+		}
+		names[field.Name] = true
+
+		displayName := field.DisplayName
+		if displayName == "" {
+			displayName = field.Name
+		}
+		description := fmt.Sprintf("The %s custom field of the incident.", displayName)
+		if field.Description != "" {
+			description += " " + strings.TrimSpace(field.Description)
+		}
+		c = append(c, &plugin.Column{
+			Name:        field.Name,
+			Description: description,
+			Type:        field.columnType(),
+			Hydrate:     getPagerDutyIncidentCustomFieldValues,
+			Transform:   transform.FromField(field.Name),
+		})
+	}
+	return c
+}
+
+// columnType returns the type of the column of the custom field
+func (f incidentCustomField) columnType() proto.ColumnType {
+	// Fields with several values, e.g. the affected regions, are lists
+	if strings.HasPrefix(f.FieldType, "multi_value") {
+		return proto.ColumnType_JSON
+	}
+
+	switch f.DataType {
+	case "integer":
+		return proto.ColumnType_INT
+	case "float":
+		return proto.ColumnType_DOUBLE
+	case "boolean":
+		return proto.ColumnType_BOOL
+	case "datetime":
+		return proto.ColumnType_TIMESTAMP
+	default:
+		return proto.ColumnType_STRING
+	}
+}
+
+//// HYDRATE FUNCTIONS
+
+// getPagerDutyIncidentCustomFieldValues returns the values of the custom
+// fields of the incident, by name
+func getPagerDutyIncidentCustomFieldValues(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	data := h.Item.(incident)
+
+	// Create client
+	client, err := getSessionConfig(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident.getPagerDutyIncidentCustomFieldValues", "connection_error", err)
+		return nil, err
+	}
+
+	// The client doesn't support custom fields, so call the API directly
+	getValues := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+		var resp struct {
+			CustomFields []incidentCustomFieldValue `json:"custom_fields"`
+		}
+		if err := client.getJSON(ctx, "/incidents/"+url.PathEscape(data.Id)+"/custom_fields/values", nil, &resp); err != nil {
+			return nil, err
+		}
+		return resp.CustomFields, nil
+	}
+	getResponse, err := plugin.RetryHydrate(ctx, d, h, getValues, retryConfig(d))
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident.getPagerDutyIncidentCustomFieldValues", "query_error", err)
+		return nil, err
+	}
+
+	values := map[string]interface{}{}
+	for _, field := range getResponse.([]incidentCustomFieldValue) {
+		values[field.Name] = field.Value
+	}
+	return values, nil
+}
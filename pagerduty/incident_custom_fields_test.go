@@ -0,0 +1,212 @@
+package pagerduty
+
+import (
+	"net/http"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-pagerduty/internal/fakeserver"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestIncidentCustomFieldColumns(t *testing.T) {
+	server := newTestServer(t)
+
+	p := startTestPlugin(t, server)
+	types := map[string]proto.ColumnType{}
+	statusColumns := 0
+	for _, column := range tableSchema(t, p, testConnectionName, "pagerduty_incident").Columns {
+		types[column.Name] = column.Type
+		if column.Name == "status" {
+			statusColumns++
+		}
+	}
+
+	want := map[string]proto.ColumnType{
+		"customer_impact":      proto.ColumnType_STRING,
+		"impacted_users":       proto.ColumnType_INT,
+		"root_cause_confirmed": proto.ColumnType_BOOL,
+		"detected_at":          proto.ColumnType_TIMESTAMP,
+		"affected_regions":     proto.ColumnType_JSON,
+	}
+	for name, columnType := range want {
+		if got, ok := types[name]; !ok || got != columnType {
+			t.Errorf("got column %s of type %v (defined %v), want %v", name, got, ok, columnType)
+		}
+	}
+	// A custom field named like a column of the table doesn't replace it
+	if statusColumns != 1 {
+		t.Errorf("got %d status columns, want 1", statusColumns)
+	}
+}
+
+func TestIncidentCustomFieldColumnsWithoutAccess(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(server *fakeserver.Server) []queryOption
+	}{
+		{
+			name: "forbidden",
+			setup: func(server *fakeserver.Server) []queryOption {
+				server.Fail("/incidents/custom_fields", http.StatusForbidden, 1)
+				return nil
+			},
+		},
+		{
+			name: "server error",
+			setup: func(server *fakeserver.Server) []queryOption {
+				server.Fail("/incidents/custom_fields", http.StatusInternalServerError, 1)
+				return nil
+			},
+		},
+		{
+			name: "unreachable API",
+			setup: func(server *fakeserver.Server) []queryOption {
+				server.Close()
+				return nil
+			},
+		},
+		{
+			name: "failing token_command",
+			setup: func(server *fakeserver.Server) []queryOption {
+				return []queryOption{withConfig(func(config *pagerDutyConfig) {
+					command := "echo 'not signed in' >&2; exit 1"
+					config.Token = nil
+					config.TokenCommand = &command
+				})}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(t)
+			opts := test.setup(server)
+
+			// The connection starts with the table without custom fields
+			p := startTestPlugin(t, server, opts...)
+			columns := map[string]bool{}
+			for _, column := range tableSchema(t, p, testConnectionName, "pagerduty_incident").Columns {
+				columns[column.Name] = true
+			}
+			if !columns["id"] {
+				t.Errorf("got no id column, want the table without custom fields")
+			}
+			if columns["customer_impact"] {
+				t.Errorf("got a customer_impact column, want the table without custom fields")
+			}
+		})
+	}
+}
+
+func TestIncidentCustomFieldValues(t *testing.T) {
+	server := newTestServer(t)
+
+	// The values are only fetched for the custom field columns
+	if _, err := queryRows(t, server, "pagerduty_incident", withColumns("id", "status")); err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if got := len(server.Requests("/incidents/" + fakeserver.IncidentID + "/custom_fields/values")); got != 0 {
+		t.Errorf("got %d requests for the custom field values, want none", got)
+	}
+
+	rows, err := queryRows(t, server, "pagerduty_incident",
+		withColumns("id", "customer_impact", "impacted_users", "root_cause_confirmed", "detected_at", "affected_regions"),
+	)
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+
+	want := map[string]map[string]interface{}{
+		fakeserver.IncidentID: {
+			"customer_impact":      "degraded",
+			"impacted_users":       int64(1200),
+			"root_cause_confirmed": false,
+			"detected_at":          timestamp("2024-03-01T09:55:00Z"),
+			"affected_regions":     []interface{}{"eu-west-1", "us-east-1"},
+		},
+		fakeserver.SecondIncidentID: {
+			"customer_impact":  nil,
+			"impacted_users":   nil,
+			"affected_regions": nil,
+		},
+	}
+	for id, columns := range want {
+		row := findRow(t, rows, "id", id)
+		for column, want := range columns {
+			if got := row[column]; !reflect.DeepEqual(got, want) {
+				t.Errorf("incident %s: got %s = %#v, want %#v", id, column, got, want)
+			}
+		}
+	}
+}
+
+func TestIncidentCustomFieldColumnsInAnAggregator(t *testing.T) {
+	// The accounts have different custom fields: the fixtures, the fixtures
+	// and a deploy_id field, and none as they can't be read
+	fixtures := newTestServer(t)
+	extraField := newTestServer(t)
+	extraField.Seed("/incidents/custom_fields",
+		fakeserver.Object{"id": "PFIELD9", "type": "field", "name": "deploy_id", "display_name": "Deploy ID", "data_type": "string", "field_type": "single_value"},
+	)
+	extraField.Seed("/incidents/"+fakeserver.IncidentID+"/custom_fields/values",
+		fakeserver.Object{"id": "PFIELD9", "type": "field_value", "name": "deploy_id", "value": "d-42"},
+	)
+	noAccess := newTestServer(t)
+	noAccess.Fail("/incidents/custom_fields", http.StatusForbidden, 1)
+
+	children := map[string]*fakeserver.Server{"fixtures": fixtures, "extra_field": extraField, "no_access": noAccess}
+	var connections []*proto.ConnectionConfig
+	var childNames []string
+	for name, server := range children {
+		connections = append(connections, &proto.ConnectionConfig{Connection: name, Config: configHCL(newTestQuery(server).config)})
+		childNames = append(childNames, name)
+	}
+	connections = append(connections, &proto.ConnectionConfig{Connection: "all", ChildConnections: slices.Clone(childNames)})
+	p := startPlugin(t, connections...)
+
+	// The aggregator has the columns of every child
+	columns := map[string]bool{}
+	for _, column := range tableSchema(t, p, "all", "pagerduty_incident").Columns {
+		columns[column.Name] = true
+	}
+	for _, name := range []string{"id", "customer_impact", "deploy_id"} {
+		if !columns[name] {
+			t.Errorf("got no %s column in the aggregator", name)
+		}
+	}
+
+	rows, err := executeQuery(t, p, "all", childNames, "pagerduty_incident", newTestQuery(fixtures, withColumns("id", "_ctx", "customer_impact", "deploy_id")))
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if len(rows) != 6 {
+		t.Fatalf("got %d rows, want 2 for each child", len(rows))
+	}
+
+	// Each child shows the values of its own custom fields, and NULL for the
+	// fields it doesn't have
+	want := map[string]map[string]interface{}{
+		"fixtures":    {"customer_impact": "degraded", "deploy_id": nil},
+		"extra_field": {"customer_impact": "degraded", "deploy_id": "d-42"},
+		"no_access":   {"customer_impact": nil, "deploy_id": nil},
+	}
+	for name, columns := range want {
+		var row map[string]interface{}
+		for _, r := range rows {
+			if r["sp_connection_name"] == p.connection(name) && r["id"] == fakeserver.IncidentID {
+				row = r
+			}
+		}
+		if row == nil {
+			t.Errorf("got no incident %s of %s", fakeserver.IncidentID, name)
+			continue
+		}
+		for column, value := range columns {
+			if got := row[column]; got != value {
+				t.Errorf("%s: got %s = %v, want %v", name, column, got, value)
+			}
+		}
+	}
+}
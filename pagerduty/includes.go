@@ -0,0 +1,37 @@
+package pagerduty
+
+import (
+	"encoding/json"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// includedObject is a reference to another object, e.g. the service of an
+// incident. When the object is included in the response with include[], the
+// API returns the full object instead, which go-pagerduty's APIObject would
+// drop. The object is kept as returned, and shown as is in JSON columns.
+type includedObject struct {
+	pagerduty.APIObject
+
+	fields map[string]interface{}
+}
+
+func (o *includedObject) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &o.APIObject); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &o.fields)
+}
+
+func (o includedObject) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.fields)
+}
+
+// includedObjectIDs returns the IDs of the objects
+func includedObjectIDs(objects []includedObject) []string {
+	ids := make([]string, 0, len(objects))
+	for _, object := range objects {
+		ids = append(ids, object.ID)
+	}
+	return ids
+}
@@ -0,0 +1,221 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/google/go-querystring/query"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// logEntry is a log entry as returned by the API. The objects it references
+// are decoded as includedObject, so they are complete when they're included.
+// The fields of the log entry are kept as returned too, since each type of
+// log entry has fields of its own, e.g. the assignees of an assign_log_entry.
+type logEntry struct {
+	pagerduty.LogEntry
+
+	Incident *includedObject  `json:"incident,omitempty"`
+	Service  *includedObject  `json:"service,omitempty"`
+	Teams    []includedObject `json:"teams,omitempty"`
+
+	fields map[string]interface{}
+}
+
+func (e *logEntry) UnmarshalJSON(data []byte) error {
+	type plainLogEntry logEntry
+	if err := json.Unmarshal(data, (*plainLogEntry)(e)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &e.fields)
+}
+
+type listLogEntriesResponse struct {
+	pagerduty.APIListObject
+	LogEntries []logEntry `json:"log_entries"`
+}
+
+// logEntriesPage returns the page of the account's log entries matching req,
+// in any of the teams if there are some. The client can't filter log entries
+// by team, and drops the fields of included services, so the API is called
+// directly.
+func logEntriesPage(ctx context.Context, client *pagerDutyClient, req pagerduty.ListLogEntriesOptions, teamIDs []string, page pageRequest) (*pageResult[logEntry], error) {
+	req.APIListObject.Limit = page.Limit
+	req.APIListObject.Offset = page.Offset
+	values, err := query.Values(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, teamID := range teamIDs {
+		values.Add("team_ids[]", teamID)
+	}
+
+	var resp listLogEntriesResponse
+	if err := client.getJSON(ctx, "/log_entries", values, &resp); err != nil {
+		return nil, err
+	}
+	return offsetPage(resp.LogEntries, resp.APIListObject), nil
+}
+
+// logEntryFieldPaths maps the typed columns of log entries to the path of
+// their value in the log entries of any type.
+var logEntryFieldPaths = map[string]string{
+	"agent_id":        "agent.id",
+	"agent_type":      "agent.type",
+	"channel_type":    "channel.type",
+	"channel_summary": "channel.summary",
+}
+
+// logEntryTypeFieldPaths maps log entry types to the paths of the typed
+// columns that only the log entries of that type have. A new type of log entry
+// is supported by adding its paths here.
+var logEntryTypeFieldPaths = map[string]map[string]string{
+	"assign_log_entry": {
+		"assigned_user_id": "assignees.0.id",
+	},
+	"escalate_log_entry": {
+		"assigned_user_id": "assignees.0.id",
+	},
+	"notify_log_entry": {
+		"notification_type":    "channel.notification.type",
+		"notification_address": "channel.notification.address",
+	},
+}
+
+// logEntryColumns adds the columns that flatten the agent, channel and
+// type-specific fields of log entries to the tables of log entries
+func logEntryColumns(c []*plugin.Column) []*plugin.Column {
+	return append(c, []*plugin.Column{
+		{
+			Name:        "agent_id",
+			Description: "An unique identifier of the agent (user, service or integration) that created or modified the incident.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.From(logEntryField),
+		},
+		{
+			Name:        "agent_type",
+			Description: "The type of the agent, e.g. user_reference, service_reference or generic_email_inbound_integration_reference.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.From(logEntryField),
+		},
+		{
+			Name:        "channel_type",
+			Description: "The means by which the action was channeled, e.g. api, email, sms, website or timeout.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.From(logEntryField),
+		},
+		{
+			Name:        "channel_summary",
+			Description: "A summary of the channel, e.g. the subject of the email that triggered the incident.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.From(logEntryField),
+		},
+		{
+			Name:        "notification_type",
+			Description: "The type of notification sent to the user, e.g. sms_notification, phone_notification, email_notification or push_notification. Only set for notify_log_entry.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.From(logEntryField),
+		},
+		{
+			Name:        "notification_address",
+			Description: "The address the notification was sent to, e.g. a phone number or an email address. Only set for notify_log_entry.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.From(logEntryField),
+		},
+		{
+			Name:        "assigned_user_id",
+			Description: "An unique identifier of the first user the incident was assigned to. Only set for assign_log_entry and escalate_log_entry.",
+			Type:        proto.ColumnType_STRING,
+			Transform:   transform.From(logEntryField),
+		},
+		{
+			Name:        "assigned_user_ids",
+			Description: "The unique identifiers of all the users the incident was assigned to. Only set for assign_log_entry and escalate_log_entry.",
+			Type:        proto.ColumnType_JSON,
+			Transform:   transform.From(logEntryAssignedUserIDs),
+		},
+	}...)
+}
+
+// logEntryField returns the value of the column's field in the log entry, as
+// mapped by logEntryTypeFieldPaths and logEntryFieldPaths, or nil if the log
+// entry doesn't have it
+func logEntryField(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	var entry logEntry
+	switch item := d.HydrateItem.(type) {
+	case logEntry:
+		entry = item
+	case *logEntry:
+		entry = *item
+	default:
+		return nil, nil
+	}
+
+	path, ok := logEntryTypeFieldPaths[entry.Type][d.ColumnName]
+	if !ok {
+		path, ok = logEntryFieldPaths[d.ColumnName]
+	}
+	if !ok {
+		return nil, nil
+	}
+	return fieldAtPath(entry.fields, path), nil
+}
+
+// logEntryAssignedUserIDs returns the IDs of all the users the log entry
+// assigned the incident to, for the types of log entries that have an
+// assigned_user_id
+func logEntryAssignedUserIDs(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	var entry logEntry
+	switch item := d.HydrateItem.(type) {
+	case logEntry:
+		entry = item
+	case *logEntry:
+		entry = *item
+	default:
+		return nil, nil
+	}
+
+	if _, ok := logEntryTypeFieldPaths[entry.Type]["assigned_user_id"]; !ok {
+		return nil, nil
+	}
+	return logEntryAssigneeIDs(entry), nil
+}
+
+// logEntryAssigneeIDs returns the IDs of the users a log entry assigned the
+// incident to
+func logEntryAssigneeIDs(entry logEntry) []string {
+	assignees, _ := fieldAtPath(entry.fields, "assignees").([]interface{})
+	ids := []string{}
+	for _, assignee := range assignees {
+		if id, ok := fieldAtPath(assignee, "id").(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// fieldAtPath returns the value at the dot separated path in the decoded JSON
+// value, where a number indexes a list, or nil if there is none
+func fieldAtPath(value interface{}, path string) interface{} {
+	for _, key := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value = v[key]
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil
+			}
+			value = v[i]
+		default:
+			return nil
+		}
+	}
+	return value
+}
@@ -0,0 +1,120 @@
+package pagerduty
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-pagerduty/internal/fakeserver"
+)
+
+func TestLogEntryTypedColumns(t *testing.T) {
+	server := newTestServer(t)
+	user := fakeserver.Object{"id": fakeserver.UserID, "type": "user_reference"}
+	integration := fakeserver.Object{"id": fakeserver.IntegrationID, "type": "generic_email_inbound_integration_reference"}
+	// Newest first, as the API lists them
+	server.Seed("/incidents/"+fakeserver.SecondIncidentID+"/log_entries",
+		fakeserver.Object{
+			"id":        "PLOG204",
+			"type":      "escalate_log_entry",
+			"agent":     user,
+			"channel":   fakeserver.Object{"type": "timeout"},
+			"assignees": []fakeserver.Object{{"id": fakeserver.UserID, "type": "user_reference"}, {"id": fakeserver.SecondUserID, "type": "user_reference"}},
+		},
+		fakeserver.Object{
+			"id":      "PLOG203",
+			"type":    "notify_log_entry",
+			"agent":   user,
+			"channel": fakeserver.Object{"type": "sms", "notification": fakeserver.Object{"type": "sms_notification", "address": "+15555550100", "status": "success"}},
+		},
+		fakeserver.Object{
+			"id":        "PLOG202",
+			"type":      "assign_log_entry",
+			"agent":     user,
+			"channel":   fakeserver.Object{"type": "website"},
+			"assignees": []fakeserver.Object{{"id": fakeserver.SecondUserID, "type": "user_reference"}},
+		},
+		fakeserver.Object{
+			"id":      "PLOG201",
+			"type":    "trigger_log_entry",
+			"agent":   integration,
+			"channel": fakeserver.Object{"type": "email", "summary": "Disk full on db-2"},
+		},
+	)
+
+	rows, err := queryRows(t, server, "pagerduty_incident_log", withQual("incident_id", "=", fakeserver.SecondIncidentID))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("got %d rows, want 4", len(rows))
+	}
+
+	sortRows(rows, "id")
+	want := []map[string]interface{}{
+		{
+			"id":                "PLOG204",
+			"channel_type":      "timeout",
+			"assigned_user_id":  fakeserver.UserID,
+			"assigned_user_ids": []interface{}{fakeserver.UserID, fakeserver.SecondUserID},
+		},
+		{
+			"id":                   "PLOG203",
+			"agent_id":             fakeserver.UserID,
+			"agent_type":           "user_reference",
+			"channel_type":         "sms",
+			"channel_summary":      nil,
+			"notification_type":    "sms_notification",
+			"notification_address": "+15555550100",
+			"assigned_user_id":     nil,
+			"assigned_user_ids":    nil,
+		},
+		{
+			"id":                   "PLOG202",
+			"channel_type":         "website",
+			"notification_type":    nil,
+			"notification_address": nil,
+			"assigned_user_id":     fakeserver.SecondUserID,
+			"assigned_user_ids":    []interface{}{fakeserver.SecondUserID},
+		},
+		{
+			"id":                "PLOG201",
+			"agent_id":          fakeserver.IntegrationID,
+			"agent_type":        "generic_email_inbound_integration_reference",
+			"channel_type":      "email",
+			"channel_summary":   "Disk full on db-2",
+			"notification_type": nil,
+			"assigned_user_id":  nil,
+		},
+	}
+	for i, columns := range want {
+		for column, want := range columns {
+			if got := rows[len(rows)-1-i][column]; !reflect.DeepEqual(got, want) {
+				t.Errorf("row %d: got %s = %#v, want %#v", i, column, got, want)
+			}
+		}
+	}
+}
+
+func TestFieldAtPath(t *testing.T) {
+	value := map[string]interface{}{
+		"channel":   map[string]interface{}{"type": "sms"},
+		"assignees": []interface{}{map[string]interface{}{"id": "PUSER01"}},
+	}
+
+	tests := []struct {
+		path string
+		want interface{}
+	}{
+		{path: "channel.type", want: "sms"},
+		{path: "assignees.0.id", want: "PUSER01"},
+		{path: "assignees.1.id", want: nil},
+		{path: "assignees.first.id", want: nil},
+		{path: "channel.type.name", want: nil},
+		{path: "agent.id", want: nil},
+	}
+	for _, test := range tests {
+		if got := fieldAtPath(value, test.path); got != test.want {
+			t.Errorf("fieldAtPath(%q) = %#v, want %#v", test.path, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package pagerduty
+
+import (
+	"context"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// maxPageSize is the largest page the REST API returns
+const maxPageSize = uint(100)
+
+// pageRequest is the page for a pageFunc to fetch. Offset based endpoints use
+// Limit and Offset, cursor based endpoints use Limit and Cursor.
+type pageRequest struct {
+	Limit  uint
+	Offset uint
+	Cursor string
+}
+
+// pageResult is a single page of results. More is set when there are further
+// pages at NextOffset, or at NextCursor for cursor based endpoints.
+type pageResult[T any] struct {
+	Items      []T
+	More       bool
+	NextOffset uint
+	NextCursor string
+}
+
+// pageFunc fetches a single page of results
+type pageFunc[T any] func(ctx context.Context, req pageRequest) (*pageResult[T], error)
+
+// offsetPage returns the page of items described by the APIListObject of an
+// offset based list response
+func offsetPage[T any](items []T, list pagerduty.APIListObject) *pageResult[T] {
+	return &pageResult[T]{
+		Items:      items,
+		More:       list.More,
+		NextOffset: list.Offset + list.Limit,
+	}
+}
+
+// paginate fetches each page in turn, applying the retry policy, and streams
+// its items as soon as they arrive. It stops once every page is fetched, the
+// query's LIMIT is reached or the query is cancelled.
+func paginate[T any](ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, fetch pageFunc[T]) error {
+	return forEachPage(ctx, d, h, fetch, func(item T) bool {
+		d.StreamListItem(ctx, item)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+}
+
+// forEachPage fetches each page in turn, applying the retry policy, and passes
+// its items to yield. It stops once every page is fetched, or yield returns
+// false.
+func forEachPage[T any](ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, fetch pageFunc[T], yield func(T) bool) error {
+	req := pageRequest{Limit: pageLimit(d)}
+
+	listPage := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+		return fetch(ctx, req)
+	}
+	for {
+		listPageResponse, err := plugin.RetryHydrate(ctx, d, h, listPage, retryConfig(d))
+		if err != nil {
+			return err
+		}
+		page := listPageResponse.(*pageResult[T])
+
+		for _, item := range page.Items {
+			if !yield(item) {
+				return nil
+			}
+		}
+
+		if !page.More {
+			return nil
+		}
+		if page.NextCursor != "" {
+			req.Cursor = page.NextCursor
+			continue
+		}
+
+		// Guard against a response that would fetch the same page forever
+		if page.NextOffset <= req.Offset {
+			return nil
+		}
+		req.Offset = page.NextOffset
+	}
+}
+
+// pageLimit returns the page size to request, which is reduced down if the
+// user has only requested a small number of rows
+func pageLimit(d *plugin.QueryData) uint {
+	limit := maxPageSize
+	if d.QueryContext.Limit != nil && *d.QueryContext.Limit > 0 && uint(*d.QueryContext.Limit) < limit {
+		limit = uint(*d.QueryContext.Limit)
+	}
+	return limit
+}
+
+// filteredPageLimit returns the page size to request for a page whose items
+// are filtered after they are fetched, if filtered is true. The query's LIMIT
+// counts the items that remain, so full pages are requested and the listing
+// is stopped by RowsRemaining instead.
+func filteredPageLimit(page pageRequest, filtered bool) uint {
+	if filtered {
+		return maxPageSize
+	}
+	return page.Limit
+}
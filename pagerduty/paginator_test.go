@@ -0,0 +1,131 @@
+package pagerduty
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-pagerduty/internal/fakeserver"
+)
+
+// seedUsers starts a fake server with n users
+func seedUsers(t *testing.T, n int) *fakeserver.Server {
+	t.Helper()
+	server := fakeserver.New()
+	t.Cleanup(server.Close)
+	for i := 0; i < n; i++ {
+		server.Seed("/users", fakeserver.Object{"id": fmt.Sprintf("PUSER%03d", i), "type": "user", "name": fmt.Sprintf("User %d", i)})
+	}
+	return server
+}
+
+func TestPaginateFetchesEveryPage(t *testing.T) {
+	server := seedUsers(t, 250)
+
+	rows, err := queryRows(t, server, "pagerduty_user", withColumns("id", "name"))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 250 {
+		t.Errorf("got %d rows, want 250", len(rows))
+	}
+
+	requests := server.Requests("/users")
+	if len(requests) != 3 {
+		t.Fatalf("got %d requests, want 3", len(requests))
+	}
+	for i, req := range requests {
+		// The client leaves out an offset of zero
+		got, want := req.Query.Get("offset"), fmt.Sprint(i*100)
+		if got == "" {
+			got = "0"
+		}
+		if got != want {
+			t.Errorf("request %d has offset %s, want %s", i, got, want)
+		}
+		if got := req.Query.Get("limit"); got != "100" {
+			t.Errorf("request %d has limit %s, want 100", i, got)
+		}
+	}
+}
+
+func TestPaginateStopsAtLimit(t *testing.T) {
+	server := seedUsers(t, 250)
+
+	rows, err := queryRows(t, server, "pagerduty_user", withColumns("id", "name"), withLimit(5))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 5 {
+		t.Errorf("got %d rows, want 5", len(rows))
+	}
+
+	requests := server.Requests("/users")
+	if len(requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(requests))
+	}
+	if got := requests[0].Query.Get("limit"); got != "5" {
+		t.Errorf("got limit %s, want 5", got)
+	}
+}
+
+func TestPaginateRetriesRateLimiting(t *testing.T) {
+	server := seedUsers(t, 3)
+	server.Fail("/users", http.StatusTooManyRequests, 2)
+
+	rows, err := queryRows(t, server, "pagerduty_user", withColumns("id", "name"))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Errorf("got %d rows, want 3", len(rows))
+	}
+	if got := len(server.Requests("/users")); got != 3 {
+		t.Errorf("got %d requests, want 3", got)
+	}
+}
+
+func TestPaginateGivesUpAfterMaxRetries(t *testing.T) {
+	server := seedUsers(t, 3)
+	server.Fail("/users", http.StatusInternalServerError, 10)
+
+	_, err := queryRows(t, server, "pagerduty_user", withColumns("id", "name"), withConfig(func(config *pagerDutyConfig) {
+		maxRetries := 2
+		config.MaxRetries = &maxRetries
+	}))
+	if err == nil {
+		t.Fatal("list succeeded, want an error")
+	}
+	if got := len(server.Requests("/users")); got != 3 {
+		t.Errorf("got %d requests, want 3", got)
+	}
+}
+
+func TestPaginateDoesNotRetryClientErrors(t *testing.T) {
+	server := seedUsers(t, 3)
+	server.Fail("/users", http.StatusBadRequest, 1)
+
+	_, err := queryRows(t, server, "pagerduty_user", withColumns("id", "name"))
+	if err == nil {
+		t.Fatal("list succeeded, want an error")
+	}
+	if got := len(server.Requests("/users")); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
+
+func TestGetRetriesServerErrors(t *testing.T) {
+	server := newTestServer(t)
+	server.Fail("/users/"+fakeserver.UserID, http.StatusServiceUnavailable, 1)
+
+	row, err := queryRow(t, server, "pagerduty_user", withQual("id", "=", fakeserver.UserID))
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if row == nil {
+		t.Fatal("got no row")
+	}
+	if got := len(server.Requests("/users/" + fakeserver.UserID)); got != 2 {
+		t.Errorf("got %d requests, want 2", got)
+	}
+}
@@ -15,27 +15,64 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 		Name:             pluginName,
 		DefaultTransform: transform.FromCamel().Transform(transform.NullIfZeroValue),
 		DefaultGetConfig: &plugin.GetConfig{},
+		DefaultIgnoreConfig: &plugin.IgnoreConfig{
+			ShouldIgnoreErrorFunc: shouldIgnoreError,
+		},
+		// Aggregator queries with a subdomain qual only query the matching connections
+		ConnectionKeyColumns: []plugin.ConnectionKeyColumn{
+			{
+				Name:    "subdomain",
+				Hydrate: getPagerDutySubdomain,
+			},
+		},
 		ConnectionConfigSchema: &plugin.ConnectionConfigSchema{
 			NewInstance: ConfigInstance,
 		},
-		TableMap: map[string]*plugin.Table{
-			"pagerduty_escalation_policy":   tablePagerDutyEscalationPolicy(ctx),
-			"pagerduty_incident":            tablePagerDutyIncident(ctx),
-			"pagerduty_incident_log":        tablePagerDutyIncidentLog(ctx),
-			"pagerduty_on_call":             tablePagerDutyOnCall(ctx),
-			"pagerduty_priority":            tablePagerDutyPriority(ctx),
-			"pagerduty_ruleset":             tablePagerDutyRuleset(ctx),
-			"pagerduty_ruleset_rule":        tablePagerDutyRulesetRule(ctx),
-			"pagerduty_schedule":            tablePagerDutySchedule(ctx),
-			"pagerduty_schedule_user":       tablePagerDutyScheduleUser(ctx),
-			"pagerduty_service":             tablePagerDutyService(ctx),
-			"pagerduty_service_integration": tablePagerDutyServiceIntegration(ctx),
-			"pagerduty_tag":                 tablePagerDutyTag(ctx),
-			"pagerduty_team":                tablePagerDutyTeam(ctx),
-			"pagerduty_user":                tablePagerDutyUser(ctx),
-			"pagerduty_vendor":              tablePagerDutyVendor(ctx),
-		},
+		// The columns of pagerduty_incident depend on the custom fields of the
+		// account
+		SchemaMode:   plugin.SchemaModeDynamic,
+		TableMapFunc: pluginTableDefinitions,
 	}
 
 	return p
 }
+
+// pluginTableDefinitions returns the tables of the connection. A column is
+// added to pagerduty_incident for each incident custom field of the account.
+func pluginTableDefinitions(ctx context.Context, d *plugin.TableMapData) (map[string]*plugin.Table, error) {
+	// The plugin is still usable without the custom fields, e.g. when the
+	// credentials can't read them or the API can't be reached, so fall back
+	// to the tables without them rather than failing the connection
+	customFields, err := listIncidentCustomFields(ctx, d.Connection)
+	if err != nil {
+		plugin.Logger(ctx).Warn("pluginTableDefinitions", "custom_fields_error", err)
+		return tableDefinitions(ctx, nil), nil
+	}
+
+	return tableDefinitions(ctx, customFields), nil
+}
+
+// tableDefinitions returns the tables, with the given incident custom fields
+func tableDefinitions(ctx context.Context, customFields []incidentCustomField) map[string]*plugin.Table {
+	return map[string]*plugin.Table{
+		"pagerduty_escalation_policy":       tablePagerDutyEscalationPolicy(ctx),
+		"pagerduty_incident":                tablePagerDutyIncident(ctx, customFields),
+		"pagerduty_incident_alert":          tablePagerDutyIncidentAlert(ctx),
+		"pagerduty_incident_log":            tablePagerDutyIncidentLog(ctx),
+		"pagerduty_incident_note":           tablePagerDutyIncidentNote(ctx),
+		"pagerduty_incident_state_interval": tablePagerDutyIncidentStateInterval(ctx),
+		"pagerduty_log_entry":               tablePagerDutyLogEntry(ctx),
+		"pagerduty_on_call":                 tablePagerDutyOnCall(ctx),
+		"pagerduty_priority":                tablePagerDutyPriority(ctx),
+		"pagerduty_ruleset":                 tablePagerDutyRuleset(ctx),
+		"pagerduty_ruleset_rule":            tablePagerDutyRulesetRule(ctx),
+		"pagerduty_schedule":                tablePagerDutySchedule(ctx),
+		"pagerduty_schedule_user":           tablePagerDutyScheduleUser(ctx),
+		"pagerduty_service":                 tablePagerDutyService(ctx),
+		"pagerduty_service_integration":     tablePagerDutyServiceIntegration(ctx),
+		"pagerduty_tag":                     tablePagerDutyTag(ctx),
+		"pagerduty_team":                    tablePagerDutyTeam(ctx),
+		"pagerduty_user":                    tablePagerDutyUser(ctx),
+		"pagerduty_vendor":                  tablePagerDutyVendor(ctx),
+	}
+}
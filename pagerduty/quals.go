@@ -0,0 +1,85 @@
+package pagerduty
+
+import (
+	"slices"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// The values of the enum columns that can be filtered on
+var (
+	incidentStatuses  = []string{"triggered", "acknowledged", "resolved"}
+	incidentUrgencies = []string{"high", "low"}
+	alertStatuses     = []string{"triggered", "resolved"}
+)
+
+// enumFilter is the values of an enum column that match its quals
+type enumFilter struct {
+	// in is the values the column must be one of, or nil for any value
+	in []string
+}
+
+// enumQualFilter returns the filter of the = and IN quals of the enum column.
+// <> and NOT IN aren't key column operators, so they are checked by Postgres:
+// the SDK caches rows by key column quals only, and runs a query with a single
+// list qual as one list call per value with =, which would turn NOT IN into IN.
+func enumQualFilter(d *plugin.QueryData, column string) enumFilter {
+	var filter enumFilter
+	if d.Quals[column] != nil {
+		for _, q := range d.Quals[column].Quals {
+			if q.Operator != "=" {
+				continue
+			}
+			values := qualStringValues(q.Value)
+			if filter.in != nil {
+				values = slices.DeleteFunc(values, func(value string) bool {
+					return !slices.Contains(filter.in, value)
+				})
+			}
+			filter.in = values
+		}
+	}
+	return filter
+}
+
+// matches returns true if the value matches the filter
+func (f enumFilter) matches(value string) bool {
+	return f.in == nil || slices.Contains(f.in, value)
+}
+
+// enumQualValues returns the values of the enum column that match its = and
+// IN quals, for an API filter that takes several values.
+//
+// It returns nil if every value matches, so no filter is needed, and false if
+// no value matches, so the query has no rows.
+func enumQualValues(d *plugin.QueryData, column string, enum []string) ([]string, bool) {
+	filter := enumQualFilter(d, column)
+
+	var values []string
+	for _, value := range enum {
+		if filter.matches(value) {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return nil, false
+	}
+	if len(values) == len(enum) {
+		return nil, true
+	}
+	return values, true
+}
+
+// qualStringValues returns the string values of a qual, which has a list of
+// values for IN
+func qualStringValues(value *proto.QualValue) []string {
+	if list := value.GetListValue(); list != nil {
+		values := make([]string, 0, len(list.Values))
+		for _, v := range list.Values {
+			values = append(values, v.GetStringValue())
+		}
+		return values
+	}
+	return []string{value.GetStringValue()}
+}
@@ -0,0 +1,52 @@
+package pagerduty
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
+)
+
+func TestEnumQualValues(t *testing.T) {
+	qual := func(operator string, value interface{}) *proto.Qual {
+		return &proto.Qual{FieldName: "status", Operator: &proto.Qual_StringValue{StringValue: operator}, Value: qualValue(value)}
+	}
+
+	tests := []struct {
+		name  string
+		quals []*proto.Qual
+		want  []string
+		ok    bool
+	}{
+		{name: "no quals", want: nil, ok: true},
+		{name: "equals", quals: []*proto.Qual{qual("=", "resolved")}, want: []string{"resolved"}, ok: true},
+		{name: "in", quals: []*proto.Qual{qual("=", []string{"acknowledged", "triggered"})}, want: []string{"triggered", "acknowledged"}, ok: true},
+		{name: "not equals is left to postgres", quals: []*proto.Qual{qual("<>", "resolved")}, want: nil, ok: true},
+		{name: "not in is left to postgres", quals: []*proto.Qual{qual("<>", []string{"resolved", "triggered"})}, want: nil, ok: true},
+		{name: "in and not equals", quals: []*proto.Qual{qual("=", []string{"triggered", "resolved"}), qual("<>", "resolved")}, want: []string{"triggered", "resolved"}, ok: true},
+		{name: "unknown value", quals: []*proto.Qual{qual("=", "unknown")}, want: nil, ok: false},
+		{name: "contradiction", quals: []*proto.Qual{qual("=", "resolved"), qual("=", "triggered")}, want: nil, ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The SDK only passes quals with key column operators as key
+			// column quals
+			d := &plugin.QueryData{Quals: plugin.KeyColumnQualMap{}}
+			for _, q := range test.quals {
+				if q.GetStringValue() == "=" {
+					if d.Quals["status"] == nil {
+						d.Quals["status"] = &plugin.KeyColumnQuals{Name: "status"}
+					}
+					d.Quals["status"].Quals = append(d.Quals["status"].Quals, quals.NewQual(q))
+				}
+			}
+			got, ok := enumQualValues(d, "status", incidentStatuses)
+			if !reflect.DeepEqual(got, test.want) || ok != test.ok {
+				t.Errorf("got %v, %v, want %v, %v", got, ok, test.want, test.ok)
+			}
+		})
+	}
+}
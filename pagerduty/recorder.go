@@ -0,0 +1,180 @@
+package pagerduty
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// redactedHeaders are replaced in recordings, as they hold credentials
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// recording is a request and its response, as saved to disk
+type recording struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header"`
+}
+
+type recordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// recordingTransport saves each request and its response to a file in dir,
+// for replayTransport to serve back later
+type recordingTransport struct {
+	base http.RoundTripper
+	dir  string
+}
+
+func newRecordingTransport(base http.RoundTripper, dir string) (*recordingTransport, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create record_dir %q: %v", dir, err)
+	}
+	return &recordingTransport{base: base, dir: dir}, nil
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	rec := recording{
+		Request: recordedRequest{
+			Method: req.Method,
+			URL:    req.URL.RequestURI(),
+			Header: redactHeader(req.Header),
+		},
+		Response: recordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(resp.Header),
+			Body:       string(body),
+		},
+	}
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(rec); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(t.dir, recordingFileName(req)), data.Bytes(), 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write to record_dir %q: %v", t.dir, err)
+	}
+
+	return resp, nil
+}
+
+// replayTransport serves the responses saved by recordingTransport, and never
+// sends a request over the network
+type replayTransport struct {
+	dir string
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	data, err := os.ReadFile(filepath.Join(t.dir, recordingFileName(req)))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return replayErrorResponse(req, "no recorded response in replay_dir"), nil
+		}
+		return nil, err
+	}
+
+	var rec recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return replayErrorResponse(req, fmt.Sprintf("invalid recording: %v", err)), nil
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Response.StatusCode, http.StatusText(rec.Response.StatusCode)),
+		StatusCode:    rec.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rec.Response.Header,
+		Body:          io.NopCloser(strings.NewReader(rec.Response.Body)),
+		ContentLength: int64(len(rec.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// recordingFileName returns the file a request is recorded in. It depends on
+// the method, path and query parameters only, so that a recording can be
+// replayed against any api_url, and query parameters in any order.
+func recordingFileName(req *http.Request) string {
+	path := req.URL.Path
+	query := req.URL.Query().Encode()
+
+	hash := sha256.Sum256([]byte(req.Method + " " + path + "?" + query))
+
+	// Prefix the hash with the path, so the recordings are easy to find
+	name := strings.Trim(strings.NewReplacer("/", "_", ".", "_").Replace(path), "_")
+	if len(name) > 100 {
+		name = name[:100]
+	}
+	return fmt.Sprintf("%s_%s_%s.json", req.Method, name, hex.EncodeToString(hash[:8]))
+}
+
+func redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	if redacted == nil {
+		redacted = http.Header{}
+	}
+	for _, key := range redactedHeaders {
+		if redacted.Get(key) != "" {
+			redacted.Set(key, "REDACTED")
+		}
+	}
+	return redacted
+}
+
+// replayErrorResponse returns an error response for a request that can't be
+// replayed. The client turns it into an API error with a status that isn't
+// retried, whereas an error returned by the transport would be retried as a
+// network failure.
+func replayErrorResponse(req *http.Request, message string) *http.Response {
+	var body bytes.Buffer
+	_ = json.NewEncoder(&body).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"message": fmt.Sprintf("%s for %s %s", message, req.Method, req.URL.RequestURI()),
+		},
+	})
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented)),
+		StatusCode:    http.StatusNotImplemented,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(&body),
+		ContentLength: int64(body.Len()),
+		Request:       req,
+	}
+}
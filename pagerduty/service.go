@@ -1,41 +1,256 @@
 package pagerduty
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
 
 	"github.com/PagerDuty/go-pagerduty"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 )
 
+// defaultOAuthTokenURL is the endpoint used to exchange scoped OAuth app
+// credentials for an access token
+const defaultOAuthTokenURL = "https://identity.pagerduty.com/oauth/token"
+
+// tokenCommandTimeout bounds how long token_command may run
+const tokenCommandTimeout = 30 * time.Second
+
 // getSessionConfig :: returns PagerDuty client to perform API requests
-func getSessionConfig(ctx context.Context, d *plugin.QueryData) (*pagerduty.Client, error) {
+func getSessionConfig(ctx context.Context, d *plugin.QueryData) (*pagerDutyClient, error) {
 	// Load clientOptions from cache
 	sessionCacheKey := "pagerduty.clientoption"
 	if cachedData, ok := d.ConnectionManager.Cache.Get(sessionCacheKey); ok {
-		return cachedData.(*pagerduty.Client), nil
+		return cachedData.(*pagerDutyClient), nil
+	}
+
+	client, err := newPagerDutyClient(ctx, d.Connection)
+	if err != nil {
+		return nil, err
 	}
 
+	// save clientOptions in cache
+	d.ConnectionManager.Cache.Set(sessionCacheKey, client)
+
+	return client, nil
+}
+
+// newPagerDutyClient returns a PagerDuty client for the connection's config
+func newPagerDutyClient(ctx context.Context, connection *plugin.Connection) (*pagerDutyClient, error) {
 	// Get pagerduty config
-	pagerDutyConfig := GetConfig(d.Connection)
+	pagerDutyConfig := GetConfig(connection)
 
-	// Get the authorization token
-	token := os.Getenv("PAGERDUTY_TOKEN")
-	if pagerDutyConfig.Token != nil {
-		token = *pagerDutyConfig.Token
+	// Get the API endpoints, e.g. https://api.eu.pagerduty.com for the EU service region
+	// If unset, the client defaults to the US service region
+	var clientOptions []pagerduty.ClientOptions
+	apiURL := os.Getenv("PAGERDUTY_API_URL")
+	if pagerDutyConfig.APIURL != nil {
+		apiURL = *pagerDutyConfig.APIURL
+	}
+	apiEndpoint := defaultAPIURL
+	if apiURL != "" {
+		apiEndpoint = normalizeEndpoint(apiURL)
+		clientOptions = append(clientOptions, pagerduty.WithAPIEndpoint(apiEndpoint))
+	}
+	if pagerDutyConfig.EventsURL != nil && *pagerDutyConfig.EventsURL != "" {
+		clientOptions = append(clientOptions, pagerduty.WithV2EventsAPIEndpoint(normalizeEndpoint(*pagerDutyConfig.EventsURL)))
 	}
 
-	// No creds
-	if token == "" {
-		return nil, fmt.Errorf("token must be configured")
+	// Serve recorded responses without any credentials or network access
+	if pagerDutyConfig.ReplayDir != nil && *pagerDutyConfig.ReplayDir != "" {
+		if pagerDutyConfig.RecordDir != nil && *pagerDutyConfig.RecordDir != "" {
+			return nil, fmt.Errorf("record_dir and replay_dir cannot both be configured")
+		}
+		dir, err := expandHomeDir(*pagerDutyConfig.ReplayDir)
+		if err != nil {
+			return nil, fmt.Errorf("invalid replay_dir %q: %v", *pagerDutyConfig.ReplayDir, err)
+		}
+
+		client := &pagerDutyClient{
+			Client:      pagerduty.NewClient("", clientOptions...),
+			apiEndpoint: apiEndpoint,
+		}
+		client.HTTPClient = &http.Client{Transport: &replayTransport{dir: dir}}
+
+		return client, nil
 	}
 
-	// Create client
-	client := pagerduty.NewClient(token)
+	// Scoped OAuth app credentials take precedence over the API token
+	clientID := os.Getenv("PAGERDUTY_CLIENT_ID")
+	if pagerDutyConfig.ClientID != nil {
+		clientID = *pagerDutyConfig.ClientID
+	}
+	clientSecret := os.Getenv("PAGERDUTY_CLIENT_SECRET")
+	if pagerDutyConfig.ClientSecret != nil {
+		clientSecret = *pagerDutyConfig.ClientSecret
+	}
 
-	// save clientOptions in cache
-	d.ConnectionManager.Cache.Set(sessionCacheKey, client)
+	// Pace requests to stay under the REST API rate limit, recording the
+	// responses if record_dir is set
+	requestsPerMinute := defaultMaxRequestsPerMinute
+	if pagerDutyConfig.MaxRequestsPerMinute != nil {
+		requestsPerMinute = *pagerDutyConfig.MaxRequestsPerMinute
+	}
+	var base http.RoundTripper = http.DefaultTransport
+	if pagerDutyConfig.RecordDir != nil && *pagerDutyConfig.RecordDir != "" {
+		dir, err := expandHomeDir(*pagerDutyConfig.RecordDir)
+		if err != nil {
+			return nil, fmt.Errorf("invalid record_dir %q: %v", *pagerDutyConfig.RecordDir, err)
+		}
+		base, err = newRecordingTransport(base, dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	transport := newRateLimitedTransport(base, requestsPerMinute)
+
+	client := &pagerDutyClient{apiEndpoint: apiEndpoint}
+	if clientID != "" || clientSecret != "" {
+		if clientID == "" || clientSecret == "" {
+			return nil, fmt.Errorf("client_id and client_secret must both be configured")
+		}
+
+		tokenURL := defaultOAuthTokenURL
+		if pagerDutyConfig.OAuthTokenURL != nil && *pagerDutyConfig.OAuthTokenURL != "" {
+			tokenURL = *pagerDutyConfig.OAuthTokenURL
+		}
+		oauthConfig := &clientcredentials.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			TokenURL:     tokenURL,
+			Scopes:       pagerDutyConfig.Scopes,
+			AuthStyle:    oauth2.AuthStyleInParams,
+		}
+
+		// The access token is fetched on first use and refreshed shortly before
+		// it expires. The cached client outlives the query that created it, so
+		// the token source must not be bound to the query context.
+		client.Client = pagerduty.NewClient("", append(clientOptions, pagerduty.WithOAuth())...)
+		client.HTTPClient = &http.Client{
+			Transport: &oauth2.Transport{
+				Source: oauthConfig.TokenSource(context.Background()),
+				Base:   transport,
+			},
+		}
+	} else {
+		// Get the authorization token
+		token, err := getToken(ctx, pagerDutyConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		// No creds
+		if token == "" {
+			return nil, fmt.Errorf("token or client_id and client_secret must be configured")
+		}
+
+		// Create client
+		client.Client = pagerduty.NewClient(token, clientOptions...)
+		client.authToken = token
+		client.HTTPClient = &http.Client{Transport: transport}
+	}
 
 	return client, nil
 }
+
+// getToken resolves the API token from the first configured source, in order:
+//  1. token
+//  2. token_file
+//  3. token_command
+//  4. the PAGERDUTY_TOKEN environment variable
+//
+// The resolved token is cached along with the client, so files and commands
+// are only read again once the cached client expires.
+func getToken(ctx context.Context, config pagerDutyConfig) (string, error) {
+	if config.Token != nil {
+		return *config.Token, nil
+	}
+
+	if config.TokenFile != nil && *config.TokenFile != "" {
+		path, err := expandHomeDir(*config.TokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read token from token_file %q: %v", *config.TokenFile, err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read token from token_file %q: %v", *config.TokenFile, err)
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", fmt.Errorf("token_file %q is empty", *config.TokenFile)
+		}
+		return token, nil
+	}
+
+	if config.TokenCommand != nil && *config.TokenCommand != "" {
+		token, err := runTokenCommand(ctx, *config.TokenCommand)
+		if err != nil {
+			return "", fmt.Errorf("failed to get token from token_command %q: %v", *config.TokenCommand, err)
+		}
+		return token, nil
+	}
+
+	return os.Getenv("PAGERDUTY_TOKEN"), nil
+}
+
+// runTokenCommand runs the command through the shell and returns the token
+// printed on stdout
+func runTokenCommand(ctx context.Context, command string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, tokenCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%v: %s", err, msg)
+		}
+		return "", err
+	}
+
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("command did not print a token")
+	}
+	return token, nil
+}
+
+// expandHomeDir replaces a leading "~" in the path with the user's home directory
+func expandHomeDir(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// normalizeEndpoint returns the endpoint as a base URL, i.e. with a scheme and
+// without a trailing slash, so that "api.eu.pagerduty.com" and
+// "https://api.eu.pagerduty.com/" are treated the same
+func normalizeEndpoint(endpoint string) string {
+	endpoint = strings.TrimRight(strings.TrimSpace(endpoint), "/")
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	return endpoint
+}
@@ -29,7 +29,7 @@ func tablePagerDutyEscalationPolicy(_ context.Context) *plugin.Table {
 			Hydrate:    getPagerDutyEscalationPolicy,
 			KeyColumns: plugin.SingleColumn("id"),
 		},
-		Columns: []*plugin.Column{
+		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "name",
 				Description: "The name of the escalation policy.",
@@ -83,11 +83,23 @@ func tablePagerDutyEscalationPolicy(_ context.Context) *plugin.Table {
 				Description: "A list of services associated with the policy.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "service_ids",
+				Description: "The IDs of the services associated with the policy.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Services").Transform(referenceIDs),
+			},
 			{
 				Name:        "teams",
 				Description: "A list of teams associated with the policy.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "team_ids",
+				Description: "The IDs of the teams associated with the policy.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Teams").Transform(referenceIDs),
+			},
 			{
 				Name:        "tags",
 				Description: "A list of tags applied on escalation policy.",
@@ -103,7 +115,7 @@ func tablePagerDutyEscalationPolicy(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromField("Name"),
 			},
-		},
+		}),
 	}
 }
 
@@ -122,47 +134,47 @@ func listPagerDutyEscalationPolicies(ctx context.Context, d *plugin.QueryData, h
 	if d.EqualsQuals["name"] != nil {
 		req.Query = d.EqualsQuals["name"].GetStringValue()
 	}
+	req.TeamIDs = configuredTeamIDs(d)
 
-	// Retrieve the list of policies
-	maxResult := uint(100)
-
-	// Reduce the basic request limit down if the user has only requested a small number of rows
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if uint(*limit) < maxResult {
-			maxResult = uint(*limit)
-		}
-	}
-	req.APIListObject.Limit = maxResult
-
-	listPage := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-		policies, err := client.ListEscalationPoliciesWithContext(ctx, req)
-		return policies, err
-	}
-	for {
-		listPageResponse, err := plugin.RetryHydrate(ctx, d, h, listPage, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+	err = paginate(ctx, d, h, func(ctx context.Context, page pageRequest) (*pageResult[pagerduty.EscalationPolicy], error) {
+		req.APIListObject.Limit = page.Limit
+		req.APIListObject.Offset = page.Offset
+		resp, err := client.ListEscalationPoliciesWithContext(ctx, req)
 		if err != nil {
-			plugin.Logger(ctx).Error("pagerduty_escalation_policy.listPagerDutyEscalationPolicies", "query_error", err)
 			return nil, err
 		}
-		listResponse := listPageResponse.(*pagerduty.ListEscalationPoliciesResponse)
+		return offsetPage(resp.EscalationPolicies, resp.APIListObject), nil
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_escalation_policy.listPagerDutyEscalationPolicies", "query_error", err)
+		return nil, err
+	}
 
-		for _, policy := range listResponse.EscalationPolicies {
-			d.StreamListItem(ctx, policy)
+	return nil, nil
+}
 
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
-			}
-		}
+// listTeamEscalationPolicyIDs returns the IDs of every escalation policy of
+// the teams. It always fetches full pages, regardless of the query's LIMIT.
+func listTeamEscalationPolicyIDs(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, client *pagerDutyClient, teamIDs []string) ([]string, error) {
+	req := pagerduty.ListEscalationPoliciesOptions{TeamIDs: teamIDs}
 
-		if !listResponse.APIListObject.More {
-			break
+	var ids []string
+	err := forEachPage(ctx, d, h, func(ctx context.Context, page pageRequest) (*pageResult[pagerduty.EscalationPolicy], error) {
+		req.APIListObject.Limit = maxPageSize
+		req.APIListObject.Offset = page.Offset
+		resp, err := client.ListEscalationPoliciesWithContext(ctx, req)
+		if err != nil {
+			return nil, err
 		}
-		req.APIListObject.Offset = listResponse.APIListObject.Offset + listResponse.APIListObject.Limit
+		return offsetPage(resp.EscalationPolicies, resp.APIListObject), nil
+	}, func(policy pagerduty.EscalationPolicy) bool {
+		ids = append(ids, policy.ID)
+		return true
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	return nil, nil
+	return ids, nil
 }
 
 //// HYDRATE FUNCTIONS
@@ -185,7 +197,7 @@ func getPagerDutyEscalationPolicy(ctx context.Context, d *plugin.QueryData, h *p
 		data, err := client.GetEscalationPolicyWithContext(ctx, id, &pagerduty.GetEscalationPolicyOptions{})
 		return data, err
 	}
-	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, retryConfig(d))
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_escalation_policy.getPagerDutyEscalationPolicy", "query_error", err)
 
@@ -196,6 +208,11 @@ func getPagerDutyEscalationPolicy(ctx context.Context, d *plugin.QueryData, h *p
 	}
 	getResp := getResponse.(*pagerduty.EscalationPolicy)
 
+	// Hide escalation policies outside of the connection's teams
+	if !inConfiguredTeams(d, teamReferenceIDs(getResp.Teams)...) {
+		return nil, nil
+	}
+
 	return *getResp, nil
 }
 
@@ -213,7 +230,7 @@ func listPagerDutyEscalationPolicyTags(ctx context.Context, d *plugin.QueryData,
 		data, err := client.GetTagsForEntityPaginated(ctx, "escalation_policies", data.ID, pagerduty.ListTagOptions{})
 		return data, err
 	}
-	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, retryConfig(d))
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_escalation_policy.listPagerDutyEscalationPolicyTags", "query_error", err)
 		return nil, err
@@ -2,18 +2,64 @@ package pagerduty
 
 import (
 	"context"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/PagerDuty/go-pagerduty"
+	"github.com/google/go-querystring/query"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 )
 
+const (
+	// maxIncidentWindow is the longest created_at range the API lists incidents for
+	maxIncidentWindow = 180 * 24 * time.Hour
+
+	// maxIncidentOffset is the furthest the API pages through incidents by offset
+	maxIncidentOffset = 10000
+
+	// defaultIncidentParentWindow is how far back the incidents of the tables
+	// listed per incident go without any incident_created_at quals
+	defaultIncidentParentWindow = 30 * 24 * time.Hour
+)
+
+// incident is an incident as returned by the API. The objects it references
+// are decoded as includedObject, so they are complete when they're included.
+type incident struct {
+	pagerduty.Incident
+
+	Service              *includedObject           `json:"service,omitempty"`
+	EscalationPolicy     *includedObject           `json:"escalation_policy,omitempty"`
+	Priority             *includedObject           `json:"priority,omitempty"`
+	FirstTriggerLogEntry *includedObject           `json:"first_trigger_log_entry,omitempty"`
+	Teams                []includedObject          `json:"teams,omitempty"`
+	Assignments          []incidentAssignment      `json:"assignments,omitempty"`
+	Acknowledgements     []incidentAcknowledgement `json:"acknowledgements,omitempty"`
+}
+
+type incidentAssignment struct {
+	At       string          `json:"at,omitempty"`
+	Assignee *includedObject `json:"assignee,omitempty"`
+}
+
+type incidentAcknowledgement struct {
+	At           string          `json:"at,omitempty"`
+	Acknowledger *includedObject `json:"acknowledger,omitempty"`
+}
+
+type listIncidentsResponse struct {
+	pagerduty.APIListObject
+	Incidents []incident `json:"incidents"`
+}
+
 //// TABLE DEFINITION
 
-func tablePagerDutyIncident(_ context.Context) *plugin.Table {
+func tablePagerDutyIncident(ctx context.Context, customFields []incidentCustomField) *plugin.Table {
 	return &plugin.Table{
 		Name:        "pagerduty_incident",
 		Description: "An incident represents a problem or an issue that needs to be addressed and resolved.",
@@ -37,13 +83,35 @@ func tablePagerDutyIncident(_ context.Context) *plugin.Table {
 					Name:    "urgency",
 					Require: plugin.Optional,
 				},
+				{
+					Name:    "service_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "team_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "assigned_user_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "time_zone",
+					Require: plugin.Optional,
+				},
 			},
 		},
 		Get: &plugin.GetConfig{
-			Hydrate:    getPagerDutyIncident,
-			KeyColumns: plugin.SingleColumn("id"),
+			Hydrate: getPagerDutyIncident,
+			// The filter columns are key columns of the get too, so that their
+			// values are shown and checked against the incident
+			KeyColumns: append(plugin.AnyColumn([]string{"id", "incident_number"}),
+				&plugin.KeyColumn{Name: "team_id", Require: plugin.Optional},
+				&plugin.KeyColumn{Name: "assigned_user_id", Require: plugin.Optional},
+				&plugin.KeyColumn{Name: "time_zone", Require: plugin.Optional},
+			),
 		},
-		Columns: []*plugin.Column{
+		Columns: commonColumns(incidentCustomFieldColumns(ctx, customFields, []*plugin.Column{
 			{
 				Name:        "id",
 				Description: "An unique identifier of the incident.",
@@ -175,6 +243,100 @@ func tablePagerDutyIncident(_ context.Context) *plugin.Table {
 				Description: "The teams involved in the incident's lifecycle.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "service_id",
+				Description: "An unique identifier of the impacted service.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Service").Transform(referenceID),
+			},
+			{
+				Name:        "escalation_policy_id",
+				Description: "An unique identifier of the escalation policy assigned to the incident.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("EscalationPolicy").Transform(referenceID),
+			},
+			{
+				Name:        "priority_id",
+				Description: "An unique identifier of the priority set for the incident.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Priority").Transform(referenceID),
+			},
+			{
+				Name:        "team_ids",
+				Description: "The IDs of the teams involved in the incident.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Teams").Transform(referenceIDs),
+			},
+			{
+				Name:        "assignee_ids",
+				Description: "The IDs of the users assigned to the incident.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Assignments").TransformP(referenceIDs, "Assignee"),
+			},
+			{
+				Name:        "first_acknowledged_at",
+				Description: "The time at which the incident was first acknowledged.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Hydrate:     getPagerDutyIncidentLifecycle,
+			},
+			{
+				Name:        "resolved_at",
+				Description: "The time at which the incident was resolved.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Hydrate:     getPagerDutyIncidentLifecycle,
+			},
+			{
+				Name:        "time_to_first_ack_seconds",
+				Description: "The number of seconds from the incident being triggered to it being first acknowledged.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getPagerDutyIncidentLifecycle,
+				Transform:   transform.FromField("TimeToFirstAckSeconds"),
+			},
+			{
+				Name:        "time_to_resolve_seconds",
+				Description: "The number of seconds from the incident being triggered to it being resolved.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getPagerDutyIncidentLifecycle,
+				Transform:   transform.FromField("TimeToResolveSeconds"),
+			},
+			{
+				Name:        "escalation_count",
+				Description: "The number of times the incident was escalated.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getPagerDutyIncidentLifecycle,
+				Transform:   transform.FromField("EscalationCount"),
+			},
+			{
+				Name:        "reassignment_count",
+				Description: "The number of times the incident was reassigned after it was triggered.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getPagerDutyIncidentLifecycle,
+				Transform:   transform.FromField("ReassignmentCount"),
+			},
+			{
+				Name:        "resolved_by",
+				Description: "The agent (user, service or integration) that resolved the incident.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getPagerDutyIncidentLifecycle,
+			},
+			{
+				Name:        "team_id",
+				Description: "An unique identifier of a team involved in the incident, used to filter the results.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("team_id"),
+			},
+			{
+				Name:        "assigned_user_id",
+				Description: "An unique identifier of a user assigned to the incident, used to filter the results.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("assigned_user_id"),
+			},
+			{
+				Name:        "time_zone",
+				Description: "The time zone in which the results are rendered, e.g. Europe/London. Defaults to the account time zone.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("time_zone"),
+			},
 
 			// Steampipe standard columns
 			{
@@ -183,7 +345,7 @@ func tablePagerDutyIncident(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromField("Summary"),
 			},
-		},
+		})),
 	}
 }
 
@@ -203,86 +365,394 @@ func listPagerDutyIncidents(ctx context.Context, d *plugin.QueryData, h *plugin.
 	if d.EqualsQuals["incident_key"] != nil {
 		req.IncidentKey = d.EqualsQuals["incident_key"].GetStringValue()
 	}
-	if d.EqualsQuals["status"] != nil {
-		req.Statuses = []string{d.EqualsQuals["status"].GetStringValue()}
+	statuses, ok := enumQualValues(d, "status", incidentStatuses)
+	if !ok {
+		return nil, nil
+	}
+	req.Statuses = statuses
+	urgencies, ok := enumQualValues(d, "urgency", incidentUrgencies)
+	if !ok {
+		return nil, nil
+	}
+	req.Urgencies = urgencies
+	if d.EqualsQuals["service_id"] != nil {
+		req.ServiceIDs = []string{d.EqualsQuals["service_id"].GetStringValue()}
+	}
+	if d.EqualsQuals["assigned_user_id"] != nil {
+		req.UserIDs = []string{d.EqualsQuals["assigned_user_id"].GetStringValue()}
+	}
+	if d.EqualsQuals["time_zone"] != nil {
+		req.TimeZone = d.EqualsQuals["time_zone"].GetStringValue()
+	}
+	req.TeamIDs = configuredTeamIDs(d)
+	if d.EqualsQuals["team_id"] != nil {
+		teamID := d.EqualsQuals["team_id"].GetStringValue()
+		// A team outside of the connection's teams has no incidents to show
+		if !inConfiguredTeams(d, teamID) {
+			return nil, nil
+		}
+		req.TeamIDs = []string{teamID}
 	}
-	if d.EqualsQuals["urgency"] != nil {
-		req.Urgencies = []string{d.EqualsQuals["urgency"].GetStringValue()}
+
+	// Check for additional models to include in response
+	// for example, services, teams, assignees
+	givenColumns := d.QueryContext.Columns
+	includeFields := buildIncidentRequestFields(ctx, givenColumns)
+	if len(includeFields) > 0 {
+		req.Includes = includeFields
 	}
 
-	quals := d.Quals
-	if quals["created_at"] != nil {
-		for _, q := range quals["created_at"].Quals {
-			givenTime := q.Value.GetTimestampValue().AsTime().UTC()
-			beforeTime := givenTime.Add(time.Duration(-1) * time.Second)
-			afterTime := givenTime.Add(time.Second * 1)
+	since, until, ok := createdAtRange(d.Quals, "created_at")
+	if !ok {
+		return nil, nil
+	}
+
+	err = streamIncidents(ctx, d, h, client, req, since, until)
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident.listPagerDutyIncidents", "query_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// listIncidentParents is the parent hydrate of the tables listed per incident.
+// It streams an incident for each incident_id qual, or lists the incidents
+// created in the incident_created_at range if there are none. Without a range,
+// the incidents created in the last 30 days are listed.
+func listIncidentParents(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	client, err := getSessionConfig(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident.listIncidentParents", "connection_error", err)
+		return nil, err
+	}
 
-			// API doesn't supports listing incidents beyond 6 months
-			// if the queried range is more than 6 months, set `date_range` attribute to 'all'
-			currentTime := time.Now().UTC()
-			diffInDays := (currentTime.Sub(givenTime).Hours()) / 24
-			if diffInDays > 180 {
-				req.DateRange = "all"
-				break
+	// The incident is only fetched when it is needed to check its team or
+	// show its creation time
+	fetch := len(configuredTeamIDs(d)) > 0 || slices.Contains(d.QueryContext.Columns, "incident_created_at")
+	if d.EqualsQuals["incident_id"] != nil {
+		for _, id := range qualStringValues(d.EqualsQuals["incident_id"]) {
+			parent := incident{Incident: pagerduty.Incident{Id: id}}
+			if fetch {
+				data, err := getIncident(ctx, d, h, client, id, nil)
+				if err != nil {
+					if isNotFoundError(err) {
+						continue
+					}
+					plugin.Logger(ctx).Error("pagerduty_incident.listIncidentParents", "query_error", err)
+					return nil, err
+				}
+				if !inConfiguredTeams(d, includedObjectIDs(data.Teams)...) {
+					continue
+				}
+				parent = *data
 			}
+			d.StreamListItem(ctx, parent)
 
-			switch q.Operator {
-			case ">":
-				req.Since = convertTimeString(afterTime)
-			case ">=":
-				req.Since = convertTimeString(givenTime)
-			case "=":
-				req.Since = convertTimeString(beforeTime)
-				req.Until = convertTimeString(afterTime)
-			case "<=":
-				req.Until = convertTimeString(afterTime)
-			case "<":
-				req.Until = convertTimeString(givenTime)
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
 			}
 		}
+		return nil, nil
 	}
 
-	// Retrieve the list of incidents
-	maxResult := uint(100)
+	// Without incident_created_at quals, only the recent incidents are listed
+	since, until, ok := createdAtRange(d.Quals, "incident_created_at")
+	if !ok {
+		return nil, nil
+	}
+	if since == nil && until == nil {
+		defaultSince := time.Now().UTC().Add(-defaultIncidentParentWindow)
+		since = &defaultSince
+	}
 
-	// Reduce the basic request limit down if the user has only requested a small number of rows
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if uint(*limit) < maxResult {
-			maxResult = uint(*limit)
-		}
+	req := pagerduty.ListIncidentsOptions{TeamIDs: configuredTeamIDs(d)}
+	err = streamIncidents(ctx, d, h, client, req, since, until)
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident.listIncidentParents", "query_error", err)
+		return nil, err
 	}
-	req.APIListObject.Limit = maxResult
 
-	listPage := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-		incidents, err := client.ListIncidentsWithContext(ctx, req)
-		return incidents, err
+	return nil, nil
+}
+
+// streamIncidents streams the incidents matching req that were created between
+// since and until. Without since, the API's default range is used, unless
+// there is an until.
+func streamIncidents(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, client *pagerDutyClient, req pagerduty.ListIncidentsOptions, since *time.Time, until *time.Time) error {
+	// The API only lists incidents for up to 180 days at a time, so split the
+	// range into windows and fetch them in parallel
+	windows, err := incidentWindows(ctx, d, h, client, req, since, until)
+	if err != nil {
+		return err
 	}
-	for {
-		listPageResponse, err := plugin.RetryHydrate(ctx, d, h, listPage, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+
+	// Rows are streamed with the caller's context, which child lists keep
+	// using after the windows are stopped
+	streamCtx := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	incidents := make(chan incident)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var listErr error
+	sem := make(chan struct{}, maxConcurrency(d))
+	for _, window := range windows {
+		wg.Add(1)
+		go func(window incidentWindow) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+
+			err := listIncidentsInWindow(ctx, d, h, client, req, window, func(incident incident) bool {
+				select {
+				case incidents <- incident:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			})
+			// Errors caused by stopping the other windows are not reported
+			if err != nil && ctx.Err() == nil {
+				errOnce.Do(func() { listErr = err })
+				cancel()
+			}
+		}(window)
+	}
+	go func() {
+		wg.Wait()
+		close(incidents)
+	}()
+
+	// Rows are streamed from this goroutine only, as the list call expects
+	for incident := range incidents {
+		d.StreamListItem(streamCtx, incident)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(streamCtx) == 0 {
+			cancel()
+			break
+		}
+	}
+	// Wait for the windows to stop
+	for range incidents {
+	}
+
+	return listErr
+}
+
+// incidentWindow is a created_at range to list incidents for. A zero bound is
+// left for the API to default.
+type incidentWindow struct {
+	Since time.Time
+	Until time.Time
+}
+
+// incidentWindows returns the windows to list the incidents matching req that
+// were created between since and until. Without since, the API's default
+// range is used, unless there is an until.
+func incidentWindows(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, client *pagerDutyClient, req pagerduty.ListIncidentsOptions, since *time.Time, until *time.Time) ([]incidentWindow, error) {
+	// With only an upper bound, start from the earliest matching incident
+	if until != nil && since == nil {
+		earliest, err := getEarliestIncidentCreatedAt(ctx, d, h, client, req)
 		if err != nil {
-			plugin.Logger(ctx).Error("pagerduty_incident.listPagerDutyIncidents", "query_error", err)
 			return nil, err
 		}
-		listResponse := listPageResponse.(*pagerduty.ListIncidentsResponse)
+		if earliest == nil {
+			return nil, nil
+		}
+		since = earliest
+	}
 
-		for _, incident := range listResponse.Incidents {
-			d.StreamListItem(ctx, incident)
+	// Without any created_at quals, the API returns the most recent incidents
+	if since == nil {
+		return []incidentWindow{{}}, nil
+	}
+	end := time.Now().UTC()
+	if until != nil {
+		end = *until
+	}
+	return splitIncidentWindows(*since, end), nil
+}
 
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
+// createdAtRange returns the range of the quals on the timestamp column. It
+// returns false if the quals can't match any row.
+func createdAtRange(quals plugin.KeyColumnQualMap, column string) (since *time.Time, until *time.Time, ok bool) {
+	if quals[column] == nil {
+		return nil, nil, true
+	}
+
+	setSince := func(t time.Time) {
+		if since == nil || t.After(*since) {
+			since = &t
+		}
+	}
+	setUntil := func(t time.Time) {
+		if until == nil || t.Before(*until) {
+			until = &t
+		}
+	}
+	for _, q := range quals[column].Quals {
+		givenTime := q.Value.GetTimestampValue().AsTime().UTC()
+		beforeTime := givenTime.Add(time.Duration(-1) * time.Second)
+		afterTime := givenTime.Add(time.Second * 1)
+
+		switch q.Operator {
+		case ">":
+			setSince(afterTime)
+		case ">=":
+			setSince(givenTime)
+		case "=":
+			setSince(beforeTime)
+			setUntil(afterTime)
+		case "<=":
+			setUntil(afterTime)
+		case "<":
+			setUntil(givenTime)
+		}
+	}
+
+	if since != nil && until != nil && !since.Before(*until) {
+		return nil, nil, false
+	}
+	return since, until, true
+}
+
+// splitIncidentWindows splits the range into consecutive windows that are no
+// longer than the API allows
+func splitIncidentWindows(since, until time.Time) []incidentWindow {
+	var windows []incidentWindow
+	for start := since; start.Before(until); start = start.Add(maxIncidentWindow) {
+		end := start.Add(maxIncidentWindow)
+		if end.After(until) {
+			end = until
+		}
+		windows = append(windows, incidentWindow{Since: start, Until: end})
+	}
+	return windows
+}
+
+// listIncidentsInWindow passes the incidents created in the window to yield,
+// oldest first, until yield returns false.
+//
+// The API stops paging by offset at 10,000 incidents. Before reaching that,
+// the window is continued from the created_at of the last incident, skipping
+// the incidents already seen at that time.
+func listIncidentsInWindow(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, client *pagerDutyClient, req pagerduty.ListIncidentsOptions, window incidentWindow, yield func(incident) bool) error {
+	req.SortBy = "created_at:asc"
+	if !window.Until.IsZero() {
+		req.Until = convertTimeString(window.Until)
+	}
+
+	since := window.Since
+	seen := map[string]bool{}
+	for {
+		if !since.IsZero() {
+			req.Since = convertTimeString(since)
+		}
+
+		// The latest created_at streamed, and the incidents created at that time
+		var last time.Time
+		lastIDs := map[string]bool{}
+		capped := false
+		stopped := false
+
+		err := forEachPage(ctx, d, h, func(ctx context.Context, page pageRequest) (*pageResult[incident], error) {
+			req.APIListObject.Limit = page.Limit
+			req.APIListObject.Offset = page.Offset
+			resp, err := listIncidentsPage(ctx, client, req)
+			if err != nil {
+				return nil, err
+			}
+
+			items := make([]incident, 0, len(resp.Incidents))
+			for _, incident := range resp.Incidents {
+				if !seen[incident.Id] {
+					items = append(items, incident)
+				}
+			}
+			result := offsetPage(items, resp.APIListObject)
+			if result.More && result.NextOffset+page.Limit > maxIncidentOffset {
+				result.More = false
+				capped = true
+			}
+			return result, nil
+		}, func(incident incident) bool {
+			if createdAt, err := time.Parse(time.RFC3339, incident.CreatedAt); err == nil {
+				if createdAt.After(last) {
+					last = createdAt
+					lastIDs = map[string]bool{}
+				}
+				if createdAt.Equal(last) {
+					lastIDs[incident.Id] = true
+				}
 			}
+			if !yield(incident) {
+				stopped = true
+				return false
+			}
+			return true
+		})
+		if err != nil || stopped || !capped {
+			return err
 		}
 
-		if !listResponse.APIListObject.More {
-			break
+		// Guard against more incidents at a single time than can be paged through
+		if !last.After(since) {
+			plugin.Logger(ctx).Warn("pagerduty_incident.listIncidentsInWindow", "truncated_at", last)
+			return nil
 		}
+		since = last
+		seen = lastIDs
+	}
+}
 
-		req.APIListObject.Offset = listResponse.APIListObject.Offset + listResponse.APIListObject.Limit
+// getEarliestIncidentCreatedAt returns the created_at of the oldest incident
+// matching the request, or nil if there are none
+func getEarliestIncidentCreatedAt(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, client *pagerDutyClient, req pagerduty.ListIncidentsOptions) (*time.Time, error) {
+	req.DateRange = "all"
+	req.SortBy = "created_at:asc"
+	req.APIListObject.Limit = 1
+
+	listPage := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+		return client.ListIncidentsWithContext(ctx, req)
+	}
+	listPageResponse, err := plugin.RetryHydrate(ctx, d, h, listPage, retryConfig(d))
+	if err != nil {
+		return nil, err
+	}
+	listResponse := listPageResponse.(*pagerduty.ListIncidentsResponse)
+	if len(listResponse.Incidents) == 0 {
+		return nil, nil
 	}
 
-	return nil, nil
+	createdAt, err := time.Parse(time.RFC3339, listResponse.Incidents[0].CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	createdAt = createdAt.UTC()
+	return &createdAt, nil
+}
+
+// listIncidentsPage lists a page of incidents. The go-pagerduty client would
+// decode them as pagerduty.Incident, dropping the fields of included objects.
+func listIncidentsPage(ctx context.Context, client *pagerDutyClient, req pagerduty.ListIncidentsOptions) (*listIncidentsResponse, error) {
+	values, err := query.Values(req)
+	if err != nil {
+		return nil, err
+	}
+	var resp listIncidentsResponse
+	if err := client.getJSON(ctx, "/incidents", values, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
 }
 
 //// HYDRATE FUNCTIONS
@@ -296,16 +766,25 @@ func getPagerDutyIncident(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 	}
 	id := d.EqualsQuals["id"].GetStringValue()
 
+	// The API shows an incident by its number too
+	var number int64
+	if d.EqualsQuals["incident_number"] != nil {
+		number = d.EqualsQuals["incident_number"].GetInt64Value()
+		if id == "" {
+			id = strconv.FormatInt(number, 10)
+		}
+	}
+
 	// No inputs
 	if id == "" {
 		return nil, nil
 	}
 
-	getDetails := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-		data, err := client.GetIncidentWithContext(ctx, id)
-		return data, err
-	}
-	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+	// Check for additional models to include in response
+	// for example, services, teams, assignees
+	includeFields := buildIncidentRequestFields(ctx, d.QueryContext.Columns)
+
+	getResp, err := getIncident(ctx, d, h, client, id, includeFields)
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_incident.getPagerDutyIncident", "query_error", err)
 
@@ -314,11 +793,233 @@ func getPagerDutyIncident(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 		}
 		return nil, err
 	}
-	getResp := getResponse.(*pagerduty.Incident)
+
+	// Both an id and a number that don't match have no incident
+	if number != 0 && int64(getResp.IncidentNumber) != number {
+		return nil, nil
+	}
+
+	// Hide incidents outside of the connection's teams
+	if !inConfiguredTeams(d, includedObjectIDs(getResp.Teams)...) {
+		return nil, nil
+	}
+
+	// and those the team_id and assigned_user_id filters don't match
+	if q := d.EqualsQuals["team_id"]; q != nil && !slices.Contains(includedObjectIDs(getResp.Teams), q.GetStringValue()) {
+		return nil, nil
+	}
+	if q := d.EqualsQuals["assigned_user_id"]; q != nil && !slices.Contains(incidentAssigneeIDs(getResp), q.GetStringValue()) {
+		return nil, nil
+	}
 
 	return *getResp, nil
 }
 
+// incidentAssigneeIDs returns the IDs of the users assigned to the incident
+func incidentAssigneeIDs(data *incident) []string {
+	ids := make([]string, 0, len(data.Assignments))
+	for _, assignment := range data.Assignments {
+		if assignment.Assignee != nil {
+			ids = append(ids, assignment.Assignee.ID)
+		}
+	}
+	return ids
+}
+
+// getIncident fetches the incident, including the models in includes
+func getIncident(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, client *pagerDutyClient, id string, includes []string) (*incident, error) {
+	values := url.Values{}
+	for _, include := range includes {
+		values.Add("include[]", include)
+	}
+
+	getDetails := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+		var resp struct {
+			Incident incident `json:"incident"`
+		}
+		if err := client.getJSON(ctx, "/incidents/"+url.PathEscape(id), values, &resp); err != nil {
+			return nil, err
+		}
+		return &resp.Incident, nil
+	}
+	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, retryConfig(d))
+	if err != nil {
+		return nil, err
+	}
+	return getResponse.(*incident), nil
+}
+
+// incidentCreatedAt returns the creation time of the incident, from the
+// incident the row was listed for if there is one
+func incidentCreatedAt(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, incidentID string) (interface{}, error) {
+	if parent, ok := h.ParentItem.(incident); ok && parent.CreatedAt != "" {
+		return parent.CreatedAt, nil
+	}
+
+	// Create client
+	client, err := getSessionConfig(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	data, err := getIncident(ctx, d, h, client, incidentID, nil)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return data.CreatedAt, nil
+}
+
+// incidentInConfiguredTeams returns true if the incident is in one of the
+// connection's teams. The incident is only fetched if the connection is
+// restricted to teams, and one that doesn't exist is in none of them.
+func incidentInConfiguredTeams(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, client *pagerDutyClient, id string) (bool, error) {
+	if len(configuredTeamIDs(d)) == 0 {
+		return true, nil
+	}
+	data, err := getIncident(ctx, d, h, client, id, nil)
+	if err != nil {
+		if isNotFoundError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return inConfiguredTeams(d, includedObjectIDs(data.Teams)...), nil
+}
+
+// incidentLifecycle is the lifecycle of an incident, as recorded by its log
+// entries
+type incidentLifecycle struct {
+	FirstAcknowledgedAt   *time.Time
+	ResolvedAt            *time.Time
+	TimeToFirstAckSeconds *int64
+	TimeToResolveSeconds  *int64
+	EscalationCount       int
+	ReassignmentCount     int
+	ResolvedBy            *pagerduty.Agent
+}
+
+func getPagerDutyIncidentLifecycle(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	data := h.Item.(incident)
+
+	// Create client
+	client, err := getSessionConfig(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident.getPagerDutyIncidentLifecycle", "connection_error", err)
+		return nil, err
+	}
+
+	// Every log entry is needed, whatever the query's limit
+	req := pagerduty.ListIncidentLogEntriesOptions{}
+	var entries []pagerduty.LogEntry
+	err = forEachPage(ctx, d, h, func(ctx context.Context, page pageRequest) (*pageResult[pagerduty.LogEntry], error) {
+		req.APIListObject.Limit = maxPageSize
+		req.APIListObject.Offset = page.Offset
+		resp, err := client.ListIncidentLogEntriesWithContext(ctx, data.Id, req)
+		if err != nil {
+			return nil, err
+		}
+		return offsetPage(resp.LogEntries, resp.APIListObject), nil
+	}, func(entry pagerduty.LogEntry) bool {
+		entries = append(entries, entry)
+		return true
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident.getPagerDutyIncidentLifecycle", "query_error", err)
+		return nil, err
+	}
+
+	return incidentLifecycleOf(data.CreatedAt, entries), nil
+}
+
+// incidentLifecycleOf returns the lifecycle of the incident created at
+// createdAt from its log entries, which can be in any order. The assignments
+// made when the incident is triggered aren't counted as reassignments.
+func incidentLifecycleOf(createdAt string, entries []pagerduty.LogEntry) incidentLifecycle {
+	// The incident is triggered when it's created, or by its first trigger
+	// log entry if the creation time is missing
+	triggeredAt, err := time.Parse(time.RFC3339, createdAt)
+	triggered := err == nil
+	if !triggered {
+		for _, entry := range entries {
+			at, err := time.Parse(time.RFC3339, entry.CreatedAt)
+			if err != nil || entry.Type != "trigger_log_entry" {
+				continue
+			}
+			if !triggered || at.Before(triggeredAt) {
+				triggeredAt = at
+				triggered = true
+			}
+		}
+	}
+
+	var lifecycle incidentLifecycle
+	for _, entry := range entries {
+		at, err := time.Parse(time.RFC3339, entry.CreatedAt)
+		if err != nil {
+			continue
+		}
+		at = at.UTC()
+
+		switch entry.Type {
+		case "acknowledge_log_entry":
+			if lifecycle.FirstAcknowledgedAt == nil || at.Before(*lifecycle.FirstAcknowledgedAt) {
+				lifecycle.FirstAcknowledgedAt = &at
+			}
+		case "resolve_log_entry":
+			// An incident can't be reopened, but use the latest resolution in
+			// case of duplicate entries
+			if lifecycle.ResolvedAt == nil || at.After(*lifecycle.ResolvedAt) {
+				agent := entry.Agent
+				lifecycle.ResolvedAt = &at
+				lifecycle.ResolvedBy = &agent
+			}
+		case "escalate_log_entry":
+			lifecycle.EscalationCount++
+		case "assign_log_entry":
+			if triggered && at.After(triggeredAt) {
+				lifecycle.ReassignmentCount++
+			}
+		}
+	}
+
+	if triggered {
+		if lifecycle.FirstAcknowledgedAt != nil {
+			seconds := int64(lifecycle.FirstAcknowledgedAt.Sub(triggeredAt).Seconds())
+			lifecycle.TimeToFirstAckSeconds = &seconds
+		}
+		if lifecycle.ResolvedAt != nil {
+			seconds := int64(lifecycle.ResolvedAt.Sub(triggeredAt).Seconds())
+			lifecycle.TimeToResolveSeconds = &seconds
+		}
+	}
+	return lifecycle
+}
+
+func buildIncidentRequestFields(ctx context.Context, queryColumns []string) []string {
+	var fields []string
+	for _, columnName := range queryColumns {
+		switch columnName {
+		case "acknowledgements":
+			fields = append(fields, "acknowledgers")
+		case "assignments":
+			fields = append(fields, "assignees")
+		case "escalation_policy":
+			fields = append(fields, "escalation_policies")
+		case "first_trigger_log_entry":
+			fields = append(fields, "first_trigger_log_entries")
+		case "priority":
+			fields = append(fields, "priorities")
+		case "service":
+			fields = append(fields, "services")
+		case "conference_bridge", "teams":
+			fields = append(fields, columnName)
+		}
+	}
+	return fields
+}
+
 func convertTimeString(t time.Time) string {
 	return t.Format(time.RFC3339)
 }
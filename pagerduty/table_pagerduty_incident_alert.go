@@ -0,0 +1,272 @@
+package pagerduty
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/PagerDuty/go-pagerduty"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tablePagerDutyIncidentAlert(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "pagerduty_incident_alert",
+		Description: "An alert is a notification from a monitoring system or integration, which is grouped into an incident.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listIncidentParents,
+			Hydrate:       listPagerDutyIncidentAlerts,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "incident_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "status",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "alert_key",
+					Require: plugin.Optional,
+				},
+				{
+					Name:      "incident_created_at",
+					Require:   plugin.Optional,
+					Operators: []string{">", ">=", "=", "<", "<="},
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			Hydrate:    getPagerDutyIncidentAlert,
+			KeyColumns: plugin.AllColumns([]string{"incident_id", "id"}),
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Description: "An unique identifier of the alert.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "incident_id",
+				Description: "An unique identifier of the incident the alert is grouped into.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Incident").Transform(referenceID),
+			},
+			{
+				Name:        "incident_created_at",
+				Description: "The time at which the incident the alert is grouped into was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Hydrate:     getPagerDutyIncidentAlertIncidentCreatedAt,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "status",
+				Description: "The current status of the alert. Can be triggered or resolved.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "severity",
+				Description: "The magnitude of the problem as reported by the monitoring tool. Can be critical, error, warning or info.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "alert_key",
+				Description: "The alert's de-duplication key.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "suppressed",
+				Description: "Whether or not an alert is suppressed. Suppressed alerts are not created with a parent incident.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Suppressed"),
+			},
+			{
+				Name:        "created_at",
+				Description: "The date/time the alert was first triggered.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "summary",
+				Description: "A short-form, server-generated string that provides succinct, important information about an object suitable for primary labeling of an entity in a client. In many cases, this will be identical to name, though it is not intended to be an identifier.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "html_url",
+				Description: "An URL at which the entity is uniquely displayed in the Web app.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("HTMLURL").NullIfZero(),
+			},
+			{
+				Name:        "self",
+				Description: "The API show URL at which the object is accessible.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "type",
+				Description: "The type of object being created.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "body",
+				Description: "The alert's body, as sent by the monitoring tool. It contains the CEF details and any other data sent with the event.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "cef_details",
+				Description: "The alert's details, normalized to the Common Event Format (CEF).",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Body.cef_details"),
+			},
+			{
+				Name:        "service",
+				Description: "The service the alert was triggered on.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "service_id",
+				Description: "An unique identifier of the service the alert was triggered on.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Service").Transform(referenceID),
+			},
+			{
+				Name:        "integration",
+				Description: "The integration that sent the alert.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "integration_id",
+				Description: "An unique identifier of the integration that sent the alert.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Integration").Transform(referenceID),
+			},
+			{
+				Name:        "incident",
+				Description: "The incident the alert is grouped into.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Summary"),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listPagerDutyIncidentAlerts(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Get incident details
+	incidentID := h.Item.(incident).Id
+
+	// Create client
+	client, err := getSessionConfig(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident_alert.listPagerDutyIncidentAlerts", "connection_error", err)
+		return nil, err
+	}
+
+	statuses, ok := enumQualValues(d, "status", alertStatuses)
+	if !ok {
+		return nil, nil
+	}
+	alertKey := d.EqualsQuals["alert_key"].GetStringValue()
+
+	// The client can't filter alerts by alert_key, so call the API directly
+	err = paginate(ctx, d, h, func(ctx context.Context, page pageRequest) (*pageResult[pagerduty.IncidentAlert], error) {
+		query := listQuery(page)
+		for _, status := range statuses {
+			query.Add("statuses[]", status)
+		}
+		if alertKey != "" {
+			query.Set("alert_key", alertKey)
+		}
+
+		var resp pagerduty.ListAlertsResponse
+		if err := client.getJSON(ctx, "/incidents/"+url.PathEscape(incidentID)+"/alerts", query, &resp); err != nil {
+			return nil, err
+		}
+		return offsetPage(resp.Alerts, resp.APIListObject), nil
+	})
+	if err != nil {
+		// The incident may have been deleted since it was listed
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("pagerduty_incident_alert.listPagerDutyIncidentAlerts", "query_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getPagerDutyIncidentAlert(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	client, err := getSessionConfig(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident_alert.getPagerDutyIncidentAlert", "connection_error", err)
+		return nil, err
+	}
+	incidentID := d.EqualsQuals["incident_id"].GetStringValue()
+	id := d.EqualsQuals["id"].GetStringValue()
+
+	// No inputs
+	if id == "" || incidentID == "" {
+		return nil, nil
+	}
+
+	// Hide alerts of incidents outside of the connection's teams
+	ok, err := incidentInConfiguredTeams(ctx, d, h, client, incidentID)
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident_alert.getPagerDutyIncidentAlert", "query_error", err)
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	// The client doesn't pass the context on, so call the API directly
+	getDetails := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+		var resp pagerduty.IncidentAlertResponse
+		if err := client.getJSON(ctx, "/incidents/"+url.PathEscape(incidentID)+"/alerts/"+url.PathEscape(id), nil, &resp); err != nil {
+			return nil, err
+		}
+		return resp.IncidentAlert, nil
+	}
+	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, retryConfig(d))
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident_alert.getPagerDutyIncidentAlert", "query_error", err)
+
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	data := getResponse.(*pagerduty.IncidentAlert)
+	if data == nil {
+		return nil, nil
+	}
+
+	return *data, nil
+}
+
+func getPagerDutyIncidentAlertIncidentCreatedAt(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	alert := h.Item.(pagerduty.IncidentAlert)
+
+	createdAt, err := incidentCreatedAt(ctx, d, h, alert.Incident.ID)
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident_alert.getPagerDutyIncidentAlertIncidentCreatedAt", "query_error", err)
+		return nil, err
+	}
+	return createdAt, nil
+}
@@ -0,0 +1,210 @@
+package pagerduty
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-pagerduty/internal/fakeserver"
+)
+
+func TestListIncidentAlertsFilters(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []queryOption
+		statuses []string
+		alertKey string
+		ids      []string
+	}{
+		{
+			name:     "status",
+			opts:     []queryOption{withQual("status", "=", "triggered")},
+			statuses: []string{"triggered"},
+			ids:      []string{fakeserver.SecondAlertID},
+		},
+		{
+			// <> is left to Postgres
+			name: "status not equals",
+			opts: []queryOption{withQual("status", "<>", "resolved")},
+			ids:  []string{fakeserver.SecondAlertID},
+		},
+		{
+			name:     "alert_key",
+			opts:     []queryOption{withQual("alert_key", "=", "latency-1")},
+			alertKey: "latency-1",
+			ids:      []string{fakeserver.AlertID},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(t)
+
+			rows, err := queryRows(t, server, "pagerduty_incident_alert", append(test.opts, withFixtureIncidents())...)
+			if err != nil {
+				t.Fatalf("list failed: %v", err)
+			}
+			var ids []string
+			for _, row := range rows {
+				ids = append(ids, row["id"].(string))
+			}
+			if !slices.Equal(ids, test.ids) {
+				t.Errorf("got alerts %v, want %v", ids, test.ids)
+			}
+
+			for _, incidentID := range []string{fakeserver.IncidentID, fakeserver.SecondIncidentID} {
+				requests := server.Requests("/incidents/" + incidentID + "/alerts")
+				if len(requests) != 1 {
+					t.Fatalf("got %d requests for %s, want 1", len(requests), incidentID)
+				}
+				if got := requests[0].Query["statuses[]"]; !slices.Equal(got, test.statuses) {
+					t.Errorf("got statuses[]=%v, want %v", got, test.statuses)
+				}
+				if got := requests[0].Query.Get("alert_key"); got != test.alertKey {
+					t.Errorf("got alert_key=%q, want %q", got, test.alertKey)
+				}
+			}
+
+			// Incidents are listed without the alert quals
+			if got := server.Requests("/incidents")[0].Query["statuses[]"]; len(got) != 0 {
+				t.Errorf("got incident statuses[]=%v, want none", got)
+			}
+		})
+	}
+}
+
+func TestListIncidentAlertsForIncidents(t *testing.T) {
+	server := newTestServer(t)
+
+	rows, err := queryRows(t, server, "pagerduty_incident_alert", withQual("incident_id", "=", []string{fakeserver.SecondIncidentID}))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0]["id"] != fakeserver.SecondAlertID {
+		t.Errorf("got %v, want alert %s", rows, fakeserver.SecondAlertID)
+	}
+
+	// The given incidents don't need to be listed
+	if got := len(server.Requests("/incidents")); got != 0 {
+		t.Errorf("got %d incident list requests, want none", got)
+	}
+	if got := len(server.Requests("/incidents/" + fakeserver.IncidentID + "/alerts")); got != 0 {
+		t.Errorf("got %d requests for the alerts of another incident, want none", got)
+	}
+}
+
+func TestIncidentAlertColumns(t *testing.T) {
+	server := newTestServer(t)
+
+	row, err := queryRow(t, server, "pagerduty_incident_alert", withQual("incident_id", "=", fakeserver.IncidentID), withQual("id", "=", fakeserver.AlertID))
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if row == nil {
+		t.Fatal("got no row")
+	}
+
+	want := map[string]interface{}{
+		"incident_id":    fakeserver.IncidentID,
+		"service_id":     fakeserver.ServiceID,
+		"integration_id": fakeserver.IntegrationID,
+		"severity":       "critical",
+		"suppressed":     false,
+	}
+	for column, value := range want {
+		if got := row[column]; got != value {
+			t.Errorf("got %s = %v, want %v", column, got, value)
+		}
+	}
+	cefDetails, _ := row["cef_details"].(map[string]interface{})
+	if cefDetails["source_component"] != "api-gateway" {
+		t.Errorf("got cef_details %v, want the alert's CEF details", cefDetails)
+	}
+}
+
+func TestListIncidentAlertsForIncidentsCreatedInARange(t *testing.T) {
+	server := newTestServer(t)
+
+	rows, err := queryRows(t, server, "pagerduty_incident_alert",
+		withQual("incident_created_at", ">=", timestamp("2024-03-02T00:00:00Z")),
+		withQual("incident_created_at", "<", timestamp("2024-03-03T00:00:00Z")),
+	)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0]["id"] != fakeserver.SecondAlertID {
+		t.Fatalf("got %v, want alert %s", rows, fakeserver.SecondAlertID)
+	}
+	if got, want := rows[0]["incident_created_at"], timestamp("2024-03-02T10:00:00Z"); !want.Equal(got.(time.Time)) {
+		t.Errorf("got incident_created_at %v, want %v", got, want)
+	}
+
+	// The range is passed to the API
+	query := server.Requests("/incidents")[0].Query
+	if got := query.Get("since"); got != "2024-03-02T00:00:00Z" {
+		t.Errorf("got since=%q, want 2024-03-02T00:00:00Z", got)
+	}
+	if got := query.Get("until"); got != "2024-03-03T00:00:00Z" {
+		t.Errorf("got until=%q, want 2024-03-03T00:00:00Z", got)
+	}
+}
+
+func TestListIncidentAlertsForRecentIncidents(t *testing.T) {
+	server := newTestServer(t)
+
+	before := time.Now().UTC().Add(-defaultIncidentParentWindow)
+	rows, err := queryRows(t, server, "pagerduty_incident_alert")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 0 {
+		t.Errorf("got %d alerts of older incidents, want none", len(rows))
+	}
+
+	// Without a range, only the incidents of the last 30 days are listed
+	since, err := time.Parse(time.RFC3339, server.Requests("/incidents")[0].Query.Get("since"))
+	if err != nil {
+		t.Fatalf("got no since: %v", err)
+	}
+	if since.Before(before.Truncate(time.Second)) || since.After(before.Add(time.Minute)) {
+		t.Errorf("got since=%v, want about %v", since, before)
+	}
+}
+
+func TestIncidentAlertIncidentCreatedAt(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []queryOption
+	}{
+		{
+			name: "list",
+			opts: []queryOption{withQual("incident_id", "=", fakeserver.IncidentID)},
+		},
+		{
+			name: "get",
+			opts: []queryOption{withQual("incident_id", "=", fakeserver.IncidentID), withQual("id", "=", fakeserver.AlertID)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(t)
+
+			row, err := queryRow(t, server, "pagerduty_incident_alert", append(test.opts, withColumns("id", "incident_created_at"))...)
+			if err != nil {
+				t.Fatalf("query failed: %v", err)
+			}
+			if row == nil {
+				t.Fatal("got no row")
+			}
+			if got, want := row["incident_created_at"], timestamp("2024-03-01T10:00:00Z"); !want.Equal(got.(time.Time)) {
+				t.Errorf("got incident_created_at %v, want %v", got, want)
+			}
+
+			// The incident is fetched once for its creation time
+			if got := len(server.Requests("/incidents/" + fakeserver.IncidentID)); got != 1 {
+				t.Errorf("got %d incident requests, want 1", got)
+			}
+		})
+	}
+}
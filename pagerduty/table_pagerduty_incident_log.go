@@ -2,9 +2,10 @@ package pagerduty
 
 import (
 	"context"
-	"time"
+	"net/url"
 
 	"github.com/PagerDuty/go-pagerduty"
+	"github.com/google/go-querystring/query"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
@@ -22,16 +23,16 @@ func tablePagerDutyIncidentLog(_ context.Context) *plugin.Table {
 			KeyColumns: []*plugin.KeyColumn{
 				{
 					Name:    "incident_id",
-					Require: plugin.Required,
+					Require: plugin.AnyOf,
 				},
 				{
 					Name:      "created_at",
-					Require:   plugin.Optional,
+					Require:   plugin.AnyOf,
 					Operators: []string{">", ">=", "=", "<", "<="},
 				},
 			},
 		},
-		Columns: []*plugin.Column{
+		Columns: commonColumns(logEntryColumns([]*plugin.Column{
 			{
 				Name:        "id",
 				Description: "An unique identifier of the log entry.",
@@ -40,9 +41,9 @@ func tablePagerDutyIncidentLog(_ context.Context) *plugin.Table {
 			},
 			{
 				Name:        "incident_id",
-				Description: "An unique identifier of the queried incident.",
+				Description: "An unique identifier of the incident the log entry belongs to.",
 				Type:        proto.ColumnType_STRING,
-				Transform:   transform.FromQual("incident_id"),
+				Transform:   transform.FromField("Incident").Transform(referenceID),
 			},
 			{
 				Name:        "created_at",
@@ -100,6 +101,12 @@ func tablePagerDutyIncidentLog(_ context.Context) *plugin.Table {
 				Description: "A list of team references unless included.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "team_ids",
+				Description: "The IDs of the teams involved in the log entry.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Teams").Transform(referenceIDs),
+			},
 
 			// Steampipe standard columns
 			{
@@ -108,7 +115,7 @@ func tablePagerDutyIncidentLog(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromField("ID"),
 			},
-		},
+		})),
 	}
 }
 
@@ -122,81 +129,110 @@ func listPagerDutyIncidentLogs(ctx context.Context, d *plugin.QueryData, h *plug
 		return nil, err
 	}
 
-	incidentID := d.EqualsQuals["incident_id"].GetStringValue()
-
-	req := pagerduty.ListIncidentLogEntriesOptions{}
-
-	quals := d.Quals
-	if quals["created_at"] != nil {
-		for _, q := range quals["created_at"].Quals {
-			givenTime := q.Value.GetTimestampValue().AsTime().UTC()
-			beforeTime := givenTime.Add(time.Duration(-1) * time.Second)
-			afterTime := givenTime.Add(time.Second * 1)
-
-			switch q.Operator {
-			case ">":
-				req.Since = convertTimeString(afterTime)
-			case ">=":
-				req.Since = convertTimeString(givenTime)
-			case "=":
-				req.Since = convertTimeString(beforeTime)
-				req.Until = convertTimeString(afterTime)
-			case "<=":
-				req.Until = convertTimeString(afterTime)
-			case "<":
-				req.Until = convertTimeString(givenTime)
-			}
-		}
+	since, until, ok := createdAtRange(d.Quals, "created_at")
+	if !ok {
+		return nil, nil
 	}
 
 	// Check for additional models to include in response
 	// for example, incident, service, teams
 	givenColumns := d.QueryContext.Columns
 	includeFields := buildIncidentLogRequestFields(ctx, givenColumns)
-	if len(includeFields) > 0 {
-		req.Includes = includeFields
+
+	// Without an incident, list the log entries of every incident in the
+	// range, as pagerduty_log_entry does. Listing the incidents created in the
+	// range and then their log entries would miss the log entries of incidents
+	// created before the range, and make a request per incident.
+	if d.EqualsQuals["incident_id"] == nil {
+		req := pagerduty.ListLogEntriesOptions{Includes: includeFields}
+		if since != nil {
+			req.Since = convertTimeString(*since)
+		}
+		if until != nil {
+			req.Until = convertTimeString(*until)
+		}
+		err = paginate(ctx, d, h, func(ctx context.Context, page pageRequest) (*pageResult[logEntry], error) {
+			return logEntriesPage(ctx, client, req, configuredTeamIDs(d), page)
+		})
+		if err != nil {
+			plugin.Logger(ctx).Error("pagerduty_incident_log.listPagerDutyIncidentLogs", "query_error", err)
+			return nil, err
+		}
+		return nil, nil
 	}
 
-	// Retrieve the list of incident logs
-	maxResult := uint(100)
+	req := pagerduty.ListIncidentLogEntriesOptions{Includes: includeFields}
+	if since != nil {
+		req.Since = convertTimeString(*since)
+	}
+	if until != nil {
+		req.Until = convertTimeString(*until)
+	}
 
-	// Reduce the basic request limit down if the user has only requested a small number of rows
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if uint(*limit) < maxResult {
-			maxResult = uint(*limit)
-		}
+	// The SDK lists each incident of an IN qual in parallel, so limit the
+	// incidents listed at once across those list calls
+	release, err := acquireConnectionSlot(ctx, d)
+	if err != nil {
+		return nil, err
 	}
-	req.APIListObject.Limit = maxResult
+	defer release()
 
-	listPage := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-		incidentLogs, err := client.ListIncidentLogEntriesWithContext(ctx, incidentID, req)
-		return incidentLogs, err
+	incidentID := d.EqualsQuals["incident_id"].GetStringValue()
+
+	// Log entries can't be filtered by team, so check the teams of their
+	// incident instead
+	ok, err = incidentInConfiguredTeams(ctx, d, h, client, incidentID)
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident_log.listPagerDutyIncidentLogs", "query_error", err)
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	err = listIncidentLogEntries(ctx, d, h, client, incidentID, req, func(entry logEntry) bool {
+		d.StreamListItem(ctx, entry)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident_log.listPagerDutyIncidentLogs", "query_error", err)
+		return nil, err
 	}
-	for {
-		listPageResponse, err := plugin.RetryHydrate(ctx, d, h, listPage, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+
+	return nil, nil
+}
+
+// listIncidentLogEntries passes the log entries of the incident matching req
+// to yield, until yield returns false
+func listIncidentLogEntries(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, client *pagerDutyClient, incidentID string, req pagerduty.ListIncidentLogEntriesOptions, yield func(logEntry) bool) error {
+	// The client drops the fields that only some types of log entries have,
+	// e.g. the assignees of assign_log_entry, so call the API directly
+	err := forEachPage(ctx, d, h, func(ctx context.Context, page pageRequest) (*pageResult[logEntry], error) {
+		req.APIListObject.Limit = page.Limit
+		req.APIListObject.Offset = page.Offset
+		values, err := query.Values(req)
 		if err != nil {
-			plugin.Logger(ctx).Error("pagerduty_incident_log.listPagerDutyIncidentLogs", "query_error", err)
 			return nil, err
 		}
-		listResponse := listPageResponse.(*pagerduty.ListIncidentLogEntriesResponse)
-
-		for _, logEntry := range listResponse.LogEntries {
-			d.StreamListItem(ctx, logEntry)
 
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
-			}
+		var resp listLogEntriesResponse
+		if err := client.getJSON(ctx, "/incidents/"+url.PathEscape(incidentID)+"/log_entries", values, &resp); err != nil {
+			return nil, err
 		}
-
-		if !listResponse.APIListObject.More {
-			break
+		return offsetPage(resp.LogEntries, resp.APIListObject), nil
+	}, func(entry logEntry) bool {
+		if entry.Incident == nil {
+			entry.Incident = &includedObject{APIObject: pagerduty.APIObject{ID: incidentID}}
 		}
-		req.APIListObject.Offset = listResponse.APIListObject.Offset + listResponse.APIListObject.Limit
+		return yield(entry)
+	})
+	// The incident may have been deleted since it was listed
+	if isNotFoundError(err) {
+		return nil
 	}
-
-	return nil, nil
+	return err
 }
 
 func buildIncidentLogRequestFields(ctx context.Context, queryColumns []string) []string {
@@ -0,0 +1,147 @@
+package pagerduty
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-pagerduty/internal/fakeserver"
+)
+
+func TestListIncidentLogsFanOut(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []queryOption
+		logEntries bool
+		ids        []string
+	}{
+		{
+			name: "incident_id in list",
+			opts: []queryOption{withQual("incident_id", "=", []string{fakeserver.IncidentID, fakeserver.SecondIncidentID})},
+			ids:  []string{fakeserver.LogEntryID, fakeserver.SecondLogEntryID},
+		},
+		{
+			name:       "created_at range",
+			opts:       []queryOption{withQual("created_at", ">=", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)), withQual("created_at", "<", time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC))},
+			logEntries: true,
+			ids:        []string{fakeserver.LogEntryID, fakeserver.SecondLogEntryID, "PLOG003"},
+		},
+		{
+			// The log entries added to older incidents in the range are listed too
+			name:       "created_at range after an incident was created",
+			opts:       []queryOption{withQual("created_at", ">=", time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)), withQual("created_at", "<", time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC))},
+			logEntries: true,
+			ids:        []string{fakeserver.SecondLogEntryID, "PLOG003"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(t)
+			server.Seed("/incidents/"+fakeserver.SecondIncidentID+"/log_entries", fakeserver.Object{
+				"id":         fakeserver.SecondLogEntryID,
+				"type":       "trigger_log_entry",
+				"created_at": "2024-03-02T10:00:00Z",
+				"incident":   fakeserver.Object{"id": fakeserver.SecondIncidentID, "type": "incident_reference"},
+			})
+			server.Seed("/log_entries", fakeserver.Object{
+				"id":         "PLOG003",
+				"type":       "annotate_log_entry",
+				"created_at": "2024-03-02T12:00:00Z",
+				"incident":   fakeserver.Object{"id": fakeserver.IncidentID, "type": "incident_reference"},
+			})
+
+			rows, err := queryRows(t, server, "pagerduty_incident_log", test.opts...)
+			if err != nil {
+				t.Fatalf("list failed: %v", err)
+			}
+			incidentIDs := map[string]string{
+				fakeserver.LogEntryID:       fakeserver.IncidentID,
+				fakeserver.SecondLogEntryID: fakeserver.SecondIncidentID,
+				"PLOG003":                   fakeserver.IncidentID,
+			}
+			var ids []string
+			for _, row := range rows {
+				id := row["id"].(string)
+				ids = append(ids, id)
+				if row["incident_id"] != incidentIDs[id] {
+					t.Errorf("got incident_id %v for log entry %s, want %s", row["incident_id"], id, incidentIDs[id])
+				}
+			}
+			// The incidents are listed in parallel, so the rows can come in any order
+			slices.Sort(ids)
+			if !slices.Equal(ids, test.ids) {
+				t.Errorf("got log entries %v, want %v", ids, test.ids)
+			}
+
+			if got := len(server.Requests("/log_entries")) > 0; got != test.logEntries {
+				t.Errorf("got account log entries listed %v, want %v", got, test.logEntries)
+			}
+			if got := len(server.Requests("/incidents")); got != 0 {
+				t.Errorf("got %d incident lists, want none", got)
+			}
+		})
+	}
+}
+
+func TestListIncidentLogsMaxConcurrency(t *testing.T) {
+	for _, maxConcurrency := range []int{1, 2} {
+		t.Run(fmt.Sprintf("max_concurrency %d", maxConcurrency), func(t *testing.T) {
+			server := newTestServer(t)
+			ids := []string{fakeserver.IncidentID, fakeserver.SecondIncidentID}
+			for i := 3; i <= 5; i++ {
+				id := fmt.Sprintf("PINC00%d", i)
+				server.Seed("/incidents/" + id + "/log_entries")
+				ids = append(ids, id)
+			}
+			server.Delay(50 * time.Millisecond)
+
+			// The SDK lists each incident of the IN list in a goroutine of
+			// its own. Only columns of the list are selected, so that no other
+			// requests are made.
+			_, err := queryRows(t, server, "pagerduty_incident_log",
+				withQual("incident_id", "=", ids),
+				withColumns("id", "incident_id"),
+				withConfig(func(config *pagerDutyConfig) {
+					config.MaxConcurrency = &maxConcurrency
+				}),
+			)
+			if err != nil {
+				t.Fatalf("list failed: %v", err)
+			}
+			if got := server.MaxInFlight(); got != maxConcurrency {
+				t.Errorf("got %d requests at once, want %d", got, maxConcurrency)
+			}
+		})
+	}
+}
+
+func TestListIncidentLogsRequiresAQual(t *testing.T) {
+	server := newTestServer(t)
+
+	if _, err := queryRows(t, server, "pagerduty_incident_log"); err == nil {
+		t.Fatal("got no error, want an error asking for incident_id or created_at")
+	} else if !strings.Contains(err.Error(), "incident_id") || !strings.Contains(err.Error(), "created_at") {
+		t.Errorf("got error %q, want an error asking for incident_id or created_at", err)
+	}
+	if got := len(server.Requests("/incidents")); got != 0 {
+		t.Errorf("got %d requests, want none", got)
+	}
+}
+
+func TestListIncidentLogsStopsAtTheLimit(t *testing.T) {
+	server := newTestServer(t)
+
+	rows, err := queryRows(t, server, "pagerduty_incident_log",
+		withQual("incident_id", "=", []string{fakeserver.IncidentID, fakeserver.SecondIncidentID}),
+		withLimit(1),
+	)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Errorf("got %d rows, want 1", len(rows))
+	}
+}
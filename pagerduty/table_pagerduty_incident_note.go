@@ -0,0 +1,150 @@
+package pagerduty
+
+import (
+	"context"
+
+	"github.com/PagerDuty/go-pagerduty"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tablePagerDutyIncidentNote(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "pagerduty_incident_note",
+		Description: "A note is a comment added to an incident by a responder.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listIncidentParents,
+			Hydrate:       listPagerDutyIncidentNotes,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "incident_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:      "incident_created_at",
+					Require:   plugin.Optional,
+					Operators: []string{">", ">=", "=", "<", "<="},
+				},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Description: "An unique identifier of the note.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Note.ID"),
+			},
+			{
+				Name:        "incident_id",
+				Description: "An unique identifier of the incident the note was added to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("IncidentID"),
+			},
+			{
+				Name:        "incident_created_at",
+				Description: "The time at which the incident the note was added to was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Hydrate:     getPagerDutyIncidentNoteIncidentCreatedAt,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "content",
+				Description: "The note content.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Note.Content"),
+			},
+			{
+				Name:        "created_at",
+				Description: "The time at which the note was submitted.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Note.CreatedAt"),
+			},
+			{
+				Name:        "user",
+				Description: "The user that created the note.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Note.User"),
+			},
+			{
+				Name:        "user_id",
+				Description: "An unique identifier of the user that created the note.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Note.User").Transform(referenceID),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Note.ID"),
+			},
+		}),
+	}
+}
+
+// incidentNote is a note with the ID of the incident it was added to
+type incidentNote struct {
+	IncidentID string
+	Note       *pagerduty.IncidentNote
+}
+
+//// LIST FUNCTION
+
+func listPagerDutyIncidentNotes(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Get incident details
+	incidentID := h.Item.(incident).Id
+
+	// Create client
+	client, err := getSessionConfig(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident_note.listPagerDutyIncidentNotes", "connection_error", err)
+		return nil, err
+	}
+
+	// The notes of an incident aren't paginated
+	listPage := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+		return client.ListIncidentNotesWithContext(ctx, incidentID)
+	}
+	listResponse, err := plugin.RetryHydrate(ctx, d, h, listPage, retryConfig(d))
+	if err != nil {
+		// The incident may have been deleted since it was listed
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("pagerduty_incident_note.listPagerDutyIncidentNotes", "query_error", err)
+		return nil, err
+	}
+
+	notes := listResponse.([]pagerduty.IncidentNote)
+	for _, note := range notes {
+		d.StreamListItem(ctx, &incidentNote{
+			IncidentID: incidentID,
+			Note:       &note,
+		})
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getPagerDutyIncidentNoteIncidentCreatedAt(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	note := h.Item.(*incidentNote)
+
+	createdAt, err := incidentCreatedAt(ctx, d, h, note.IncidentID)
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident_note.getPagerDutyIncidentNoteIncidentCreatedAt", "query_error", err)
+		return nil, err
+	}
+	return createdAt, nil
+}
@@ -0,0 +1,48 @@
+package pagerduty
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-pagerduty/internal/fakeserver"
+)
+
+func TestIncidentNoteColumns(t *testing.T) {
+	server := newTestServer(t)
+
+	rows, err := queryRows(t, server, "pagerduty_incident_note", withQual("incident_id", "=", fakeserver.IncidentID))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+
+	want := map[string]interface{}{
+		"id":          fakeserver.NoteID,
+		"incident_id": fakeserver.IncidentID,
+		"content":     "Rolled back the latest deploy",
+		"user_id":     fakeserver.UserID,
+	}
+	for column, value := range want {
+		if got := rows[0][column]; got != value {
+			t.Errorf("got %s = %v, want %v", column, got, value)
+		}
+	}
+	if got := len(server.Requests("/incidents/" + fakeserver.SecondIncidentID + "/notes")); got != 0 {
+		t.Errorf("got %d requests for the notes of another incident, want none", got)
+	}
+}
+
+func TestListIncidentNotesRetries(t *testing.T) {
+	server := newTestServer(t)
+	server.Fail("/incidents/"+fakeserver.IncidentID+"/notes", http.StatusServiceUnavailable, 1)
+
+	rows, err := queryRows(t, server, "pagerduty_incident_note", withQual("incident_id", "=", fakeserver.IncidentID))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Errorf("got %d rows, want 1", len(rows))
+	}
+}
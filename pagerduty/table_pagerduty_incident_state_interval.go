@@ -0,0 +1,256 @@
+package pagerduty
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// incidentStateInterval is a period during which an incident kept the same
+// state, assignees and escalation level
+type incidentStateInterval struct {
+	IncidentID      string
+	State           string
+	StartedAt       time.Time
+	EndedAt         *time.Time
+	DurationSeconds *int64
+	AssignedUserIDs []string
+	EscalationLevel int
+	LogEntryID      string
+	Agent           pagerduty.Agent
+}
+
+//// TABLE DEFINITION
+
+func tablePagerDutyIncidentStateInterval(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "pagerduty_incident_state_interval",
+		Description: "The periods during which an incident kept the same state, assignees and escalation level, computed from its log entries.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listIncidentParents,
+			Hydrate:       listPagerDutyIncidentStateIntervals,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "incident_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:      "incident_created_at",
+					Require:   plugin.Optional,
+					Operators: []string{">", ">=", "=", "<", "<="},
+				},
+			},
+		},
+		Columns: commonColumns([]*plugin.Column{
+			{
+				Name:        "incident_id",
+				Description: "An unique identifier of the incident.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("IncidentID"),
+			},
+			{
+				Name:        "incident_created_at",
+				Description: "The time at which the incident was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Hydrate:     getPagerDutyIncidentStateIntervalIncidentCreatedAt,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "state",
+				Description: "The state of the incident during the interval. Can be triggered, acknowledged or resolved.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "started_at",
+				Description: "The time at which the interval started.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "ended_at",
+				Description: "The time at which the interval ended. Null for the current interval of the incident.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "duration_seconds",
+				Description: "The length of the interval, in seconds. Null for the current interval of the incident.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "assigned_user_ids",
+				Description: "The IDs of the users the incident was assigned to during the interval.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("AssignedUserIDs"),
+			},
+			{
+				Name:        "escalation_level",
+				Description: "The escalation level of the incident during the interval, starting at 1 when the incident is triggered and increased by each escalation.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "log_entry_id",
+				Description: "An unique identifier of the log entry that started the interval.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("LogEntryID"),
+			},
+			{
+				Name:        "agent",
+				Description: "The agent (user, service or integration) that started the interval.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "agent_id",
+				Description: "An unique identifier of the agent that started the interval.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Agent").Transform(referenceID),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listPagerDutyIncidentStateIntervals(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Get incident details
+	incidentID := h.Item.(incident).Id
+
+	// Create client
+	client, err := getSessionConfig(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident_state_interval.listPagerDutyIncidentStateIntervals", "connection_error", err)
+		return nil, err
+	}
+
+	// Every log entry is needed, whatever the query's limit
+	var entries []logEntry
+	err = listIncidentLogEntries(ctx, d, h, client, incidentID, pagerduty.ListIncidentLogEntriesOptions{}, func(entry logEntry) bool {
+		entries = append(entries, entry)
+		return true
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident_state_interval.listPagerDutyIncidentStateIntervals", "query_error", err)
+		return nil, err
+	}
+
+	for _, interval := range incidentStateIntervalsOf(incidentID, entries) {
+		d.StreamListItem(ctx, interval)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getPagerDutyIncidentStateIntervalIncidentCreatedAt(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	interval := h.Item.(incidentStateInterval)
+
+	createdAt, err := incidentCreatedAt(ctx, d, h, interval.IncidentID)
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_incident_state_interval.getPagerDutyIncidentStateIntervalIncidentCreatedAt", "query_error", err)
+		return nil, err
+	}
+	return createdAt, nil
+}
+
+// incidentStateIntervalsOf returns the state intervals of the incident from
+// its log entries, which are listed newest first.
+//
+// An incident is triggered, then acknowledged until it's unacknowledged, e.g.
+// when the acknowledgement times out. Reassigning or escalating an incident
+// triggers it again. Once resolved, the incident doesn't change anymore.
+// Changes made at the same time, such as the assignment that goes with a
+// trigger, update the same interval.
+func incidentStateIntervalsOf(incidentID string, entries []logEntry) []incidentStateInterval {
+	type event struct {
+		at    time.Time
+		entry logEntry
+	}
+	var events []event
+	for i := len(entries) - 1; i >= 0; i-- {
+		at, err := time.Parse(time.RFC3339, entries[i].CreatedAt)
+		if err != nil {
+			continue
+		}
+		events = append(events, event{at: at.UTC(), entry: entries[i]})
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].at.Before(events[j].at) })
+
+	var intervals []incidentStateInterval
+	for _, e := range events {
+		var current incidentStateInterval
+		if len(intervals) > 0 {
+			current = intervals[len(intervals)-1]
+			if current.State == "resolved" {
+				break
+			}
+		} else if e.entry.Type != "trigger_log_entry" {
+			// The incident starts with its trigger
+			continue
+		}
+
+		next := current
+		switch e.entry.Type {
+		case "trigger_log_entry":
+			next.State = "triggered"
+			if next.EscalationLevel == 0 {
+				next.EscalationLevel = 1
+			}
+		case "acknowledge_log_entry":
+			next.State = "acknowledged"
+		case "unacknowledge_log_entry":
+			next.State = "triggered"
+		case "assign_log_entry":
+			next.State = "triggered"
+			next.AssignedUserIDs = logEntryAssigneeIDs(e.entry)
+		case "escalate_log_entry":
+			next.State = "triggered"
+			next.EscalationLevel++
+			if ids := logEntryAssigneeIDs(e.entry); len(ids) > 0 {
+				next.AssignedUserIDs = ids
+			}
+		case "resolve_log_entry":
+			next.State = "resolved"
+			next.AssignedUserIDs = nil
+		default:
+			continue
+		}
+
+		if len(intervals) > 0 && next.State == current.State && next.EscalationLevel == current.EscalationLevel && slices.Equal(next.AssignedUserIDs, current.AssignedUserIDs) {
+			continue
+		}
+		if len(intervals) > 0 && e.at.Equal(current.StartedAt) {
+			intervals[len(intervals)-1] = next
+			continue
+		}
+		if len(intervals) > 0 {
+			endInterval(&intervals[len(intervals)-1], e.at)
+		}
+
+		next.IncidentID = incidentID
+		next.StartedAt = e.at
+		next.EndedAt = nil
+		next.DurationSeconds = nil
+		next.LogEntryID = e.entry.ID
+		next.Agent = e.entry.Agent
+		intervals = append(intervals, next)
+	}
+	return intervals
+}
+
+// endInterval ends the interval at the given time
+func endInterval(interval *incidentStateInterval, at time.Time) {
+	seconds := int64(at.Sub(interval.StartedAt).Seconds())
+	interval.EndedAt = &at
+	interval.DurationSeconds = &seconds
+}
@@ -0,0 +1,95 @@
+package pagerduty
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-pagerduty/internal/fakeserver"
+)
+
+func TestIncidentStateIntervals(t *testing.T) {
+	server := newTestServer(t)
+	user := fakeserver.Object{"id": fakeserver.UserID, "type": "user_reference"}
+	entry := func(id string, entryType string, createdAt string, fields ...interface{}) fakeserver.Object {
+		object := fakeserver.Object{"id": id, "type": entryType, "created_at": createdAt, "agent": user}
+		for i := 0; i < len(fields); i += 2 {
+			object[fields[i].(string)] = fields[i+1]
+		}
+		return object
+	}
+	assignees := func(ids ...string) []fakeserver.Object {
+		var objects []fakeserver.Object
+		for _, id := range ids {
+			objects = append(objects, fakeserver.Object{"id": id, "type": "user_reference"})
+		}
+		return objects
+	}
+	// Newest first, as the API lists them
+	server.Seed("/incidents/"+fakeserver.SecondIncidentID+"/log_entries",
+		entry("PLOG310", "resolve_log_entry", "2024-03-02T11:00:00Z"),
+		entry("PLOG309", "notify_log_entry", "2024-03-02T10:50:00Z"),
+		entry("PLOG308", "acknowledge_log_entry", "2024-03-02T10:40:00Z"),
+		entry("PLOG306", "escalate_log_entry", "2024-03-02T10:30:00Z", "assignees", assignees(fakeserver.SecondUserID)),
+		entry("PLOG305", "unacknowledge_log_entry", "2024-03-02T10:20:00Z"),
+		entry("PLOG304", "acknowledge_log_entry", "2024-03-02T10:06:00Z"),
+		entry("PLOG303", "acknowledge_log_entry", "2024-03-02T10:05:00Z"),
+		entry("PLOG302", "assign_log_entry", "2024-03-02T10:00:00Z", "assignees", assignees(fakeserver.UserID)),
+		entry("PLOG301", "trigger_log_entry", "2024-03-02T10:00:00Z"),
+	)
+
+	rows, err := queryRows(t, server, "pagerduty_incident_state_interval", withQual("incident_id", "=", fakeserver.SecondIncidentID))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+
+	sortRows(rows, "started_at")
+
+	user1 := []interface{}{fakeserver.UserID}
+	user2 := []interface{}{fakeserver.SecondUserID}
+	want := []map[string]interface{}{
+		{"state": "triggered", "started_at": timestamp("2024-03-02T10:00:00Z"), "ended_at": timestamp("2024-03-02T10:05:00Z"), "duration_seconds": int64(300), "assigned_user_ids": user1, "escalation_level": int64(1), "log_entry_id": "PLOG301"},
+		{"state": "acknowledged", "started_at": timestamp("2024-03-02T10:05:00Z"), "ended_at": timestamp("2024-03-02T10:20:00Z"), "duration_seconds": int64(900), "assigned_user_ids": user1, "escalation_level": int64(1), "log_entry_id": "PLOG303"},
+		{"state": "triggered", "started_at": timestamp("2024-03-02T10:20:00Z"), "ended_at": timestamp("2024-03-02T10:30:00Z"), "duration_seconds": int64(600), "assigned_user_ids": user1, "escalation_level": int64(1), "log_entry_id": "PLOG305"},
+		{"state": "triggered", "started_at": timestamp("2024-03-02T10:30:00Z"), "ended_at": timestamp("2024-03-02T10:40:00Z"), "duration_seconds": int64(600), "assigned_user_ids": user2, "escalation_level": int64(2), "log_entry_id": "PLOG306"},
+		{"state": "acknowledged", "started_at": timestamp("2024-03-02T10:40:00Z"), "ended_at": timestamp("2024-03-02T11:00:00Z"), "duration_seconds": int64(1200), "assigned_user_ids": user2, "escalation_level": int64(2), "log_entry_id": "PLOG308"},
+		{"state": "resolved", "started_at": timestamp("2024-03-02T11:00:00Z"), "ended_at": nil, "duration_seconds": nil, "assigned_user_ids": nil, "escalation_level": int64(2), "log_entry_id": "PLOG310"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d intervals, want %d", len(rows), len(want))
+	}
+	for i, columns := range want {
+		for column, want := range columns {
+			if got := rows[i][column]; !reflect.DeepEqual(got, want) {
+				t.Errorf("interval %d: got %s = %#v, want %#v", i, column, got, want)
+			}
+		}
+		if got := rows[i]["incident_id"]; got != fakeserver.SecondIncidentID {
+			t.Errorf("interval %d: got incident_id %v, want %s", i, got, fakeserver.SecondIncidentID)
+		}
+	}
+}
+
+func TestIncidentStateIntervalsOfAnOpenIncident(t *testing.T) {
+	server := newTestServer(t)
+
+	rows, err := queryRows(t, server, "pagerduty_incident_state_interval", withQual("incident_id", "=", fakeserver.IncidentID))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d intervals, want 1", len(rows))
+	}
+	want := map[string]interface{}{
+		"state":            "triggered",
+		"started_at":       timestamp("2024-03-01T10:00:00Z"),
+		"ended_at":         nil,
+		"duration_seconds": nil,
+		"escalation_level": int64(1),
+		"agent_id":         fakeserver.ServiceID,
+	}
+	for column, want := range want {
+		if got := rows[0][column]; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %s = %#v, want %#v", column, got, want)
+		}
+	}
+}
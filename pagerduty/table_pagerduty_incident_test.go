@@ -0,0 +1,610 @@
+package pagerduty
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-pagerduty/internal/fakeserver"
+)
+
+// seedIncidents starts a fake server with n incidents, created interval apart
+// from start
+func seedIncidents(t *testing.T, n int, start time.Time, interval time.Duration) *fakeserver.Server {
+	t.Helper()
+	server := fakeserver.New()
+	t.Cleanup(server.Close)
+	for i := 0; i < n; i++ {
+		server.Seed("/incidents", fakeserver.Object{
+			"id":              fmt.Sprintf("PINC%05d", i),
+			"type":            "incident",
+			"incident_number": i + 1,
+			"status":          "resolved",
+			"urgency":         "high",
+			"created_at":      start.Add(time.Duration(i) * interval).UTC().Format(time.RFC3339),
+		})
+	}
+	return server
+}
+
+func incidentIDs(rows []map[string]interface{}) map[string]bool {
+	ids := map[string]bool{}
+	for _, row := range rows {
+		ids[row["id"].(string)] = true
+	}
+	return ids
+}
+
+func TestListIncidentsFilters(t *testing.T) {
+	both := []string{fakeserver.IncidentID, fakeserver.SecondIncidentID}
+	tests := []struct {
+		column string
+		value  string
+		param  string
+		ids    []string
+	}{
+		{column: "status", value: "resolved", param: "statuses[]", ids: []string{fakeserver.IncidentID}},
+		{column: "urgency", value: "low", param: "urgencies[]", ids: []string{fakeserver.SecondIncidentID}},
+		{column: "incident_key", value: "latency-1", param: "incident_key", ids: []string{fakeserver.IncidentID}},
+		{column: "service_id", value: fakeserver.ServiceID, param: "service_ids[]", ids: both},
+		{column: "team_id", value: fakeserver.TeamID, param: "team_ids[]", ids: both},
+		{column: "assigned_user_id", value: fakeserver.UserID, param: "user_ids[]", ids: []string{fakeserver.SecondIncidentID}},
+		{column: "time_zone", value: "Europe/London", param: "time_zone", ids: both},
+	}
+
+	for _, test := range tests {
+		t.Run(test.column, func(t *testing.T) {
+			server := newTestServer(t)
+
+			rows, err := queryRows(t, server, "pagerduty_incident", withQual(test.column, "=", test.value))
+			if err != nil {
+				t.Fatalf("list failed: %v", err)
+			}
+			ids := incidentIDs(rows)
+			if len(ids) != len(test.ids) {
+				t.Errorf("got incidents %v, want %v", ids, test.ids)
+			}
+			for _, id := range test.ids {
+				if !ids[id] {
+					t.Errorf("got incidents %v, want %v", ids, test.ids)
+				}
+			}
+
+			requests := server.Requests("/incidents")
+			if len(requests) != 1 {
+				t.Fatalf("got %d requests, want 1", len(requests))
+			}
+			if got := requests[0].Query.Get(test.param); got != test.value {
+				t.Errorf("got %s=%q, want %q", test.param, got, test.value)
+			}
+		})
+	}
+}
+
+func TestListIncidentsEnumQuals(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []queryOption
+		// requests is the statuses[] and urgencies[] params of each request
+		requests [][2][]string
+		ids      []string
+	}{
+		{
+			// The SDK lists the incidents of each value of a single IN
+			name:     "status in",
+			opts:     []queryOption{withQual("status", "=", []string{"triggered", "acknowledged"})},
+			requests: [][2][]string{{{"acknowledged"}, nil}, {{"triggered"}, nil}},
+			ids:      []string{fakeserver.SecondIncidentID},
+		},
+		{
+			// and passes several IN lists to the list call
+			name:     "status in and urgency in",
+			opts:     []queryOption{withQual("status", "=", []string{"triggered", "acknowledged"}), withQual("urgency", "=", []string{"high", "low"})},
+			requests: [][2][]string{{{"triggered", "acknowledged"}, nil}},
+			ids:      []string{fakeserver.SecondIncidentID},
+		},
+		{
+			// <> and NOT IN are left to Postgres
+			name:     "status not equals",
+			opts:     []queryOption{withQual("status", "<>", "resolved")},
+			requests: [][2][]string{{nil, nil}},
+			ids:      []string{fakeserver.SecondIncidentID},
+		},
+		{
+			name:     "urgency not in",
+			opts:     []queryOption{withQual("urgency", "<>", []string{"low"})},
+			requests: [][2][]string{{nil, nil}},
+			ids:      []string{fakeserver.IncidentID},
+		},
+		{
+			name:     "status in and urgency not equals",
+			opts:     []queryOption{withQual("status", "=", []string{"triggered", "resolved"}), withQual("urgency", "<>", "low")},
+			requests: [][2][]string{{{"resolved"}, nil}, {{"triggered"}, nil}},
+			ids:      []string{fakeserver.IncidentID},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(t)
+
+			rows, err := queryRows(t, server, "pagerduty_incident", test.opts...)
+			if err != nil {
+				t.Fatalf("list failed: %v", err)
+			}
+			ids := incidentIDs(rows)
+			if len(ids) != len(test.ids) || !ids[test.ids[0]] {
+				t.Errorf("got incidents %v, want %v", ids, test.ids)
+			}
+
+			// Requests for the values of an IN list are made in parallel
+			var requests [][2][]string
+			for _, request := range server.Requests("/incidents") {
+				requests = append(requests, [2][]string{request.Query["statuses[]"], request.Query["urgencies[]"]})
+			}
+			sort.Slice(requests, func(i, j int) bool {
+				return strings.Join(requests[i][0], ",") < strings.Join(requests[j][0], ",")
+			})
+			if !reflect.DeepEqual(requests, test.requests) {
+				t.Errorf("got requests with statuses[] and urgencies[] %v, want %v", requests, test.requests)
+			}
+		})
+	}
+}
+
+func TestListIncidentsWithContradictoryQuals(t *testing.T) {
+	server := newTestServer(t)
+
+	rows, err := queryRows(t, server, "pagerduty_incident", withQual("status", "=", "resolved"), withQual("status", "=", "triggered"))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 0 {
+		t.Errorf("got %d rows, want none", len(rows))
+	}
+	if got := len(server.Requests("/incidents")); got != 0 {
+		t.Errorf("got %d requests, want none", got)
+	}
+}
+
+func TestListIncidentsIncludes(t *testing.T) {
+	server := newTestServer(t)
+
+	rows, err := queryRows(t, server, "pagerduty_incident",
+		withColumns("id", "service", "service_id", "teams", "assignments", "priority", "first_trigger_log_entry", "conference_bridge"),
+		withQual("status", "=", "resolved"),
+	)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+
+	requests := server.Requests("/incidents")
+	want := []string{"services", "teams", "assignees", "priorities", "first_trigger_log_entries", "conference_bridge"}
+	if got := requests[0].Query["include[]"]; !slices.Equal(got, want) {
+		t.Errorf("got include[]=%v, want %v", got, want)
+	}
+
+	tests := []struct {
+		column string
+		field  string
+		want   interface{}
+	}{
+		{column: "service", field: "name", want: "API Gateway"},
+		{column: "priority", field: "description", want: "Critical"},
+		{column: "first_trigger_log_entry", field: "created_at", want: "2024-03-01T10:00:00Z"},
+		{column: "conference_bridge", field: "conference_url", want: "https://meet.example.com/incident-1"},
+	}
+	for _, test := range tests {
+		value, _ := rows[0][test.column].(map[string]interface{})
+		if value[test.field] != test.want {
+			t.Errorf("got %s.%s = %v, want %v", test.column, test.field, value[test.field], test.want)
+		}
+	}
+
+	teams, _ := rows[0]["teams"].([]interface{})
+	if len(teams) != 1 || teams[0].(map[string]interface{})["description"] != "Platform engineering" {
+		t.Errorf("got teams %v, want the full team", teams)
+	}
+	if got := rows[0]["service_id"]; got != fakeserver.ServiceID {
+		t.Errorf("got service_id %v, want %s", got, fakeserver.ServiceID)
+	}
+}
+
+func TestListIncidentsWithoutIncludes(t *testing.T) {
+	server := newTestServer(t)
+
+	rows, err := queryRows(t, server, "pagerduty_incident", withColumns("id", "status"))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if got := server.Requests("/incidents")[0].Query["include[]"]; len(got) != 0 {
+		t.Errorf("got include[]=%v, want none", got)
+	}
+
+	// References are shown as returned
+	service, _ := rows[0]["service"].(map[string]interface{})
+	if service["type"] != "service_reference" {
+		t.Errorf("got service %v, want a reference", service)
+	}
+}
+
+func TestGetIncidentIncludes(t *testing.T) {
+	server := newTestServer(t)
+
+	row, err := queryRow(t, server, "pagerduty_incident",
+		withQual("id", "=", fakeserver.SecondIncidentID),
+		withColumns("id", "assignments"),
+	)
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if row == nil {
+		t.Fatal("got no row")
+	}
+	if got := server.Requests("/incidents/" + fakeserver.SecondIncidentID)[0].Query["include[]"]; !slices.Equal(got, []string{"assignees"}) {
+		t.Errorf("got include[]=%v, want [assignees]", got)
+	}
+
+	assignments, _ := row["assignments"].([]interface{})
+	if len(assignments) != 1 {
+		t.Fatalf("got assignments %v, want 1", assignments)
+	}
+	assignee := assignments[0].(map[string]interface{})["assignee"].(map[string]interface{})
+	if assignee["email"] != "ada@example.com" {
+		t.Errorf("got assignee %v, want the full user", assignee)
+	}
+}
+
+func TestGetIncidentWithFilterColumns(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []queryOption
+		want bool
+	}{
+		{
+			name: "team",
+			opts: []queryOption{withQual("team_id", "=", fakeserver.TeamID)},
+			want: true,
+		},
+		{
+			name: "another team",
+			opts: []queryOption{withQual("team_id", "=", "POTHER1")},
+		},
+		{
+			name: "assignee",
+			opts: []queryOption{withQual("assigned_user_id", "=", fakeserver.UserID)},
+			want: true,
+		},
+		{
+			name: "another assignee",
+			opts: []queryOption{withQual("assigned_user_id", "=", fakeserver.SecondUserID)},
+		},
+		{
+			name: "time zone",
+			opts: []queryOption{withQual("time_zone", "=", "Europe/London")},
+			want: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(t)
+
+			opts := append([]queryOption{withQual("id", "=", fakeserver.SecondIncidentID)}, test.opts...)
+			row, err := queryRow(t, server, "pagerduty_incident", opts...)
+			if err != nil {
+				t.Fatalf("get failed: %v", err)
+			}
+			if !test.want {
+				if row != nil {
+					t.Errorf("got %v, want no row", row)
+				}
+				return
+			}
+			if row == nil || row["id"] != fakeserver.SecondIncidentID {
+				t.Fatalf("got %v, want incident %s", row, fakeserver.SecondIncidentID)
+			}
+
+			// The incident is fetched directly, not listed
+			if got := len(server.Requests("/incidents")); got != 0 {
+				t.Errorf("got %d list requests, want none", got)
+			}
+		})
+	}
+}
+
+func TestListIncidentsForATeamOutsideTheConnection(t *testing.T) {
+	server := newTestServer(t)
+
+	rows, err := queryRows(t, server, "pagerduty_incident", withQual("team_id", "=", fakeserver.TeamID), withTeamIDs("POTHER1"))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 0 {
+		t.Errorf("got %d rows, want none", len(rows))
+	}
+	if got := len(server.Requests("/incidents")); got != 0 {
+		t.Errorf("got %d requests, want none", got)
+	}
+}
+
+func TestListIncidentsSplitsLongRanges(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	server := seedIncidents(t, 50, start, 10*24*time.Hour)
+
+	// 500 days needs three windows of up to 180 days
+	end := start.Add(500 * 24 * time.Hour)
+	rows, err := queryRows(t, server, "pagerduty_incident",
+		withColumns("id", "created_at"),
+		withQual("created_at", ">=", start),
+		withQual("created_at", "<", end),
+	)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if ids := incidentIDs(rows); len(ids) != 50 || len(rows) != 50 {
+		t.Errorf("got %d rows of %d incidents, want 50", len(rows), len(ids))
+	}
+
+	requests := server.Requests("/incidents")
+	if len(requests) != 3 {
+		t.Fatalf("got %d requests, want 3", len(requests))
+	}
+	for _, req := range requests {
+		since, err := time.Parse(time.RFC3339, req.Query.Get("since"))
+		if err != nil {
+			t.Fatalf("invalid since %q", req.Query.Get("since"))
+		}
+		until, err := time.Parse(time.RFC3339, req.Query.Get("until"))
+		if err != nil {
+			t.Fatalf("invalid until %q", req.Query.Get("until"))
+		}
+		if until.Sub(since) > maxIncidentWindow {
+			t.Errorf("window %s to %s is longer than %s", since, until, maxIncidentWindow)
+		}
+	}
+}
+
+func TestListIncidentsWithOnlyAnUpperBound(t *testing.T) {
+	server := newTestServer(t)
+
+	rows, err := queryRows(t, server, "pagerduty_incident",
+		withQual("created_at", "<", time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)),
+	)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if ids := incidentIDs(rows); len(ids) != 1 || !ids[fakeserver.IncidentID] {
+		t.Errorf("got incidents %v, want %s", ids, fakeserver.IncidentID)
+	}
+
+	// The earliest incident is looked up first, to start the range from
+	requests := server.Requests("/incidents")
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(requests))
+	}
+	if got := requests[0].Query.Get("date_range"); got != "all" {
+		t.Errorf("got date_range=%q for the earliest incident, want all", got)
+	}
+}
+
+func TestListIncidentsPastTheOffsetLimit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("lists over 10,000 incidents")
+	}
+
+	// Pairs of incidents share a created_at, so the continuation has to skip
+	// the incidents already listed at that time
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	server := fakeserver.New()
+	t.Cleanup(server.Close)
+	const n = fakeserver.MaxOffset + 250
+	for i := 0; i < n; i++ {
+		server.Seed("/incidents", fakeserver.Object{
+			"id":         fmt.Sprintf("PINC%05d", i),
+			"type":       "incident",
+			"created_at": start.Add(time.Duration(i/2) * time.Minute).Format(time.RFC3339),
+		})
+	}
+
+	rows, err := queryRows(t, server, "pagerduty_incident",
+		withColumns("id", "created_at"),
+		withQual("created_at", ">=", start),
+		withQual("created_at", "<", start.Add(30*24*time.Hour)),
+	)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if ids := incidentIDs(rows); len(ids) != n || len(rows) != n {
+		t.Errorf("got %d rows of %d incidents, want %d", len(rows), len(ids), n)
+	}
+}
+
+func TestListIncidentsStopsAtLimit(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	server := seedIncidents(t, 50, start, 10*24*time.Hour)
+
+	rows, err := queryRows(t, server, "pagerduty_incident",
+		withColumns("id", "created_at"),
+		withQual("created_at", ">=", start),
+		withQual("created_at", "<", start.Add(500*24*time.Hour)),
+		withLimit(3),
+	)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Errorf("got %d rows, want 3", len(rows))
+	}
+}
+
+func TestIncidentLifecycleColumns(t *testing.T) {
+	server := newTestServer(t)
+	user := fakeserver.Object{"id": fakeserver.UserID, "type": "user_reference", "summary": "Ada Lovelace"}
+	entry := func(id string, entryType string, createdAt string) fakeserver.Object {
+		return fakeserver.Object{"id": id, "type": entryType, "created_at": createdAt, "agent": user}
+	}
+	// Newest first, as the API lists them
+	server.Seed("/incidents/"+fakeserver.SecondIncidentID+"/log_entries",
+		entry("PLOG106", "resolve_log_entry", "2024-03-02T11:00:00Z"),
+		entry("PLOG105", "acknowledge_log_entry", "2024-03-02T10:40:00Z"),
+		entry("PLOG104", "assign_log_entry", "2024-03-02T10:35:00Z"),
+		entry("PLOG103", "escalate_log_entry", "2024-03-02T10:30:00Z"),
+		entry("PLOG102", "acknowledge_log_entry", "2024-03-02T10:05:00Z"),
+		entry("PLOG101", "trigger_log_entry", "2024-03-02T10:00:00Z"),
+		entry("PLOG100", "assign_log_entry", "2024-03-02T10:00:00Z"),
+	)
+
+	row, err := queryRow(t, server, "pagerduty_incident", withQual("id", "=", fakeserver.SecondIncidentID))
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if row == nil {
+		t.Fatal("got no row")
+	}
+
+	want := map[string]interface{}{
+		"first_acknowledged_at":     timestamp("2024-03-02T10:05:00Z"),
+		"resolved_at":               timestamp("2024-03-02T11:00:00Z"),
+		"time_to_first_ack_seconds": int64(300),
+		"time_to_resolve_seconds":   int64(3600),
+		"escalation_count":          int64(1),
+		"reassignment_count":        int64(1),
+	}
+	for column, value := range want {
+		if got := row[column]; got != value {
+			t.Errorf("got %s = %v, want %v", column, got, value)
+		}
+	}
+	resolvedBy, _ := row["resolved_by"].(map[string]interface{})
+	if resolvedBy["id"] != fakeserver.UserID {
+		t.Errorf("got resolved_by %v, want %s", resolvedBy, fakeserver.UserID)
+	}
+}
+
+func TestIncidentLifecycleOfAnIncidentNeverReassigned(t *testing.T) {
+	server := newTestServer(t)
+	user := fakeserver.Object{"id": fakeserver.UserID, "type": "user_reference", "summary": "Ada Lovelace"}
+	entry := func(id string, entryType string, createdAt string) fakeserver.Object {
+		return fakeserver.Object{"id": id, "type": entryType, "created_at": createdAt, "agent": user}
+	}
+	// The incident is assigned when it's triggered, which isn't a reassignment
+	server.Seed("/incidents/"+fakeserver.SecondIncidentID+"/log_entries",
+		entry("PLOG103", "resolve_log_entry", "2024-03-02T10:30:00Z"),
+		entry("PLOG102", "acknowledge_log_entry", "2024-03-02T10:05:00Z"),
+		entry("PLOG101", "assign_log_entry", "2024-03-02T10:00:00Z"),
+		entry("PLOG100", "trigger_log_entry", "2024-03-02T10:00:00Z"),
+	)
+
+	row, err := queryRow(t, server, "pagerduty_incident", withQual("id", "=", fakeserver.SecondIncidentID), withColumns("id", "reassignment_count"))
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if row == nil {
+		t.Fatal("got no row")
+	}
+	if got := row["reassignment_count"]; got != int64(0) {
+		t.Errorf("got reassignment_count = %v, want 0", got)
+	}
+}
+
+func TestIncidentLifecycleOfAnOpenIncident(t *testing.T) {
+	server := newTestServer(t)
+
+	row, err := queryRow(t, server, "pagerduty_incident", withQual("id", "=", fakeserver.SecondIncidentID))
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if row == nil {
+		t.Fatal("got no row")
+	}
+
+	for _, column := range []string{"first_acknowledged_at", "resolved_at", "time_to_first_ack_seconds", "time_to_resolve_seconds", "resolved_by"} {
+		if got := row[column]; got != nil {
+			t.Errorf("got %s = %v, want null", column, got)
+		}
+	}
+	if got := row["escalation_count"]; got != int64(0) {
+		t.Errorf("got escalation_count = %v, want 0", got)
+	}
+}
+
+func TestGetIncidentByNumber(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []queryOption
+		id   string
+	}{
+		{
+			name: "number",
+			opts: []queryOption{withQual("incident_number", "=", int64(2))},
+			id:   fakeserver.SecondIncidentID,
+		},
+		{
+			name: "id and number",
+			opts: []queryOption{withQual("id", "=", fakeserver.SecondIncidentID), withQual("incident_number", "=", int64(2))},
+			id:   fakeserver.SecondIncidentID,
+		},
+		{
+			name: "id and another number",
+			opts: []queryOption{withQual("id", "=", fakeserver.SecondIncidentID), withQual("incident_number", "=", int64(1))},
+		},
+		{
+			name: "number and team",
+			opts: []queryOption{withQual("incident_number", "=", int64(2)), withQual("team_id", "=", fakeserver.TeamID)},
+			id:   fakeserver.SecondIncidentID,
+		},
+		{
+			name: "number and another team",
+			opts: []queryOption{withQual("incident_number", "=", int64(2)), withQual("team_id", "=", "POTHER1")},
+		},
+		{
+			name: "number and assignee",
+			opts: []queryOption{withQual("incident_number", "=", int64(2)), withQual("assigned_user_id", "=", fakeserver.UserID)},
+			id:   fakeserver.SecondIncidentID,
+		},
+		{
+			name: "number and another assignee",
+			opts: []queryOption{withQual("incident_number", "=", int64(2)), withQual("assigned_user_id", "=", fakeserver.SecondUserID)},
+		},
+		{
+			name: "missing number",
+			opts: []queryOption{withQual("incident_number", "=", int64(48213))},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(t)
+
+			row, err := queryRow(t, server, "pagerduty_incident", test.opts...)
+			if err != nil {
+				t.Fatalf("get failed: %v", err)
+			}
+			if test.id == "" {
+				if row != nil {
+					t.Errorf("got %v, want no row", row)
+				}
+				return
+			}
+			if row == nil || row["id"] != test.id {
+				t.Fatalf("got %v, want incident %s", row, test.id)
+			}
+
+			// The incident is shown straight from its number, without listing
+			if got := len(server.Requests("/incidents")); got != 0 {
+				t.Errorf("got %d list requests, want none", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package pagerduty
+
+import (
+	"context"
+	"net/url"
+	"slices"
+
+	"github.com/PagerDuty/go-pagerduty"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tablePagerDutyLogEntry(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "pagerduty_log_entry",
+		Description: "Records every change made to the incidents of the account, such as triggering, acknowledging, escalating and resolving them.",
+		List: &plugin.ListConfig{
+			Hydrate: listPagerDutyLogEntries,
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:      "created_at",
+					Require:   plugin.Optional,
+					Operators: []string{">", ">=", "=", "<", "<="},
+				},
+				{
+					Name:    "team_id",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "is_overview",
+					Require: plugin.Optional,
+				},
+				{
+					Name:    "time_zone",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		Get: &plugin.GetConfig{
+			Hydrate: getPagerDutyLogEntry,
+			// The filter columns are key columns of the get too, so that their
+			// values are shown and checked against the log entry. is_overview
+			// can't be checked against a single log entry, so it isn't one.
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "id"},
+				{Name: "team_id", Require: plugin.Optional},
+				{Name: "time_zone", Require: plugin.Optional},
+			},
+		},
+		Columns: commonColumns(logEntryColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Description: "An unique identifier of the log entry.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "type",
+				Description: "The type of the log entry, e.g. trigger_log_entry or acknowledge_log_entry.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "created_at",
+				Description: "Time at which the log entry was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "summary",
+				Description: "A short-form, server-generated string that provides succinct, important information about an object suitable for primary labeling of an entity in a client. In many cases, this will be identical to name, though it is not intended to be an identifier.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "acknowledgement_timeout",
+				Description: "Specifies the acknowledgement timeout (in seconds) for the incident.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "html_url",
+				Description: "An URL at which the entity is uniquely displayed in the Web app.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("HTMLURL").NullIfZero(),
+			},
+			{
+				Name:        "self",
+				Description: "The API show URL at which the object is accessible.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "incident",
+				Description: "The incident the log entry belongs to.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "incident_id",
+				Description: "An unique identifier of the incident the log entry belongs to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Incident").Transform(referenceID),
+			},
+			{
+				Name:        "service",
+				Description: "The service of the incident.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "service_id",
+				Description: "An unique identifier of the service of the incident.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Service").Transform(referenceID),
+			},
+			{
+				Name:        "agent",
+				Description: "The agent (user, service or integration) that created or modified the incident.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "channel",
+				Description: "Polymorphic object representation of the means by which the action was channeled. Has different formats depending on type, indicated by channel[type].",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Channel.Raw"),
+			},
+			{
+				Name:        "contexts",
+				Description: "A list of contexts to be included with the trigger such as links to graphs, or images.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "event_details",
+				Description: "A list of information about the change events.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "teams",
+				Description: "The teams of the incident.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "team_ids",
+				Description: "The IDs of the teams of the incident.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Teams").Transform(referenceIDs),
+			},
+			{
+				Name:        "team_id",
+				Description: "An unique identifier of a team of the incident, used to filter the results.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("team_id"),
+			},
+			{
+				Name:        "is_overview",
+				Description: "If true, only the log entries of the most important changes to incidents are listed.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromQual("is_overview"),
+			},
+			{
+				Name:        "time_zone",
+				Description: "The time zone in which the results are rendered, e.g. Europe/London. Defaults to the account time zone.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("time_zone"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: "Title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ID"),
+			},
+		})),
+	}
+}
+
+//// LIST FUNCTION
+
+func listPagerDutyLogEntries(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	client, err := getSessionConfig(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_log_entry.listPagerDutyLogEntries", "connection_error", err)
+		return nil, err
+	}
+
+	req := pagerduty.ListLogEntriesOptions{}
+
+	// Additional Filters
+	if d.EqualsQuals["is_overview"] != nil {
+		req.IsOverview = d.EqualsQuals["is_overview"].GetBoolValue()
+	}
+	if d.EqualsQuals["time_zone"] != nil {
+		req.TimeZone = d.EqualsQuals["time_zone"].GetStringValue()
+	}
+	teamIDs := configuredTeamIDs(d)
+	if d.EqualsQuals["team_id"] != nil {
+		teamID := d.EqualsQuals["team_id"].GetStringValue()
+		// A team outside of the connection's teams has no log entries to show
+		if !inConfiguredTeams(d, teamID) {
+			return nil, nil
+		}
+		teamIDs = []string{teamID}
+	}
+
+	since, until, ok := createdAtRange(d.Quals, "created_at")
+	if !ok {
+		return nil, nil
+	}
+	if since != nil {
+		req.Since = convertTimeString(*since)
+	}
+	if until != nil {
+		req.Until = convertTimeString(*until)
+	}
+
+	// Check for additional models to include in response
+	// for example, incidents, services, teams
+	givenColumns := d.QueryContext.Columns
+	includeFields := buildLogEntryRequestFields(ctx, givenColumns)
+	if len(includeFields) > 0 {
+		req.Includes = includeFields
+	}
+
+	err = paginate(ctx, d, h, func(ctx context.Context, page pageRequest) (*pageResult[logEntry], error) {
+		return logEntriesPage(ctx, client, req, teamIDs, page)
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_log_entry.listPagerDutyLogEntries", "query_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getPagerDutyLogEntry(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	client, err := getSessionConfig(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_log_entry.getPagerDutyLogEntry", "connection_error", err)
+		return nil, err
+	}
+	id := d.EqualsQuals["id"].GetStringValue()
+
+	// No inputs
+	if id == "" {
+		return nil, nil
+	}
+
+	// Check for additional models to include in response
+	// for example, incidents, services, teams
+	values := url.Values{}
+	for _, field := range buildLogEntryRequestFields(ctx, d.QueryContext.Columns) {
+		values.Add("include[]", field)
+	}
+	if q := d.EqualsQuals["time_zone"]; q != nil {
+		values.Set("time_zone", q.GetStringValue())
+	}
+
+	getDetails := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+		var resp struct {
+			LogEntry logEntry `json:"log_entry"`
+		}
+		if err := client.getJSON(ctx, "/log_entries/"+url.PathEscape(id), values, &resp); err != nil {
+			return nil, err
+		}
+		return &resp.LogEntry, nil
+	}
+	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, retryConfig(d))
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_log_entry.getPagerDutyLogEntry", "query_error", err)
+
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	getResp := getResponse.(*logEntry)
+
+	// Hide log entries of incidents outside of the connection's teams
+	if !inConfiguredTeams(d, includedObjectIDs(getResp.Teams)...) {
+		return nil, nil
+	}
+
+	// and those the team_id filter doesn't match
+	if q := d.EqualsQuals["team_id"]; q != nil && !slices.Contains(includedObjectIDs(getResp.Teams), q.GetStringValue()) {
+		return nil, nil
+	}
+
+	return *getResp, nil
+}
+
+func buildLogEntryRequestFields(ctx context.Context, queryColumns []string) []string {
+	var fields []string
+	for _, columnName := range queryColumns {
+		switch columnName {
+		case "incident":
+			fields = append(fields, "incidents")
+		case "service":
+			fields = append(fields, "services")
+		case "channel":
+			fields = append(fields, "channels")
+		case "teams":
+			fields = append(fields, columnName)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,177 @@
+package pagerduty
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-pagerduty/internal/fakeserver"
+)
+
+func TestListLogEntriesFilters(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []queryOption
+		query map[string]string
+		ids   []string
+	}{
+		{
+			name:  "created_at range",
+			opts:  []queryOption{withQual("created_at", ">=", time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)), withQual("created_at", "<", time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC))},
+			query: map[string]string{"since": "2024-03-02T00:00:00Z", "until": "2024-03-03T00:00:00Z"},
+			ids:   []string{fakeserver.SecondLogEntryID},
+		},
+		{
+			name:  "team_id",
+			opts:  []queryOption{withQual("team_id", "=", fakeserver.TeamID)},
+			query: map[string]string{"team_ids[]": fakeserver.TeamID},
+			ids:   []string{fakeserver.LogEntryID, fakeserver.SecondLogEntryID},
+		},
+		{
+			name:  "is_overview",
+			opts:  []queryOption{withQual("is_overview", "=", true)},
+			query: map[string]string{"is_overview": "true"},
+			ids:   []string{fakeserver.LogEntryID, fakeserver.SecondLogEntryID},
+		},
+		{
+			name:  "time_zone",
+			opts:  []queryOption{withQual("time_zone", "=", "Europe/London")},
+			query: map[string]string{"time_zone": "Europe/London"},
+			ids:   []string{fakeserver.LogEntryID, fakeserver.SecondLogEntryID},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(t)
+
+			rows, err := queryRows(t, server, "pagerduty_log_entry", test.opts...)
+			if err != nil {
+				t.Fatalf("list failed: %v", err)
+			}
+			var ids []string
+			for _, row := range rows {
+				ids = append(ids, row["id"].(string))
+			}
+			slices.Sort(ids)
+			if !slices.Equal(ids, test.ids) {
+				t.Errorf("got log entries %v, want %v", ids, test.ids)
+			}
+
+			requests := server.Requests("/log_entries")
+			if len(requests) != 1 {
+				t.Fatalf("got %d requests, want 1", len(requests))
+			}
+			for param, want := range test.query {
+				if got := requests[0].Query.Get(param); got != want {
+					t.Errorf("got %s=%q, want %q", param, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestListLogEntriesForATeamOutsideTheConnection(t *testing.T) {
+	server := newTestServer(t)
+
+	rows, err := queryRows(t, server, "pagerduty_log_entry", withQual("team_id", "=", "POTHER1"), withTeamIDs(fakeserver.TeamID))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 0 {
+		t.Errorf("got %d rows, want none", len(rows))
+	}
+	if got := len(server.Requests("/log_entries")); got != 0 {
+		t.Errorf("got %d requests, want none", got)
+	}
+}
+
+func TestListLogEntriesIncludes(t *testing.T) {
+	server := newTestServer(t)
+
+	rows, err := queryRows(t, server, "pagerduty_log_entry", withColumns("id", "incident_id", "incident", "service", "channel", "teams"))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) == 0 {
+		t.Fatal("got no rows")
+	}
+
+	row := findRow(t, rows, "id", fakeserver.LogEntryID)
+
+	want := []string{"incidents", "services", "channels", "teams"}
+	if got := server.Requests("/log_entries")[0].Query["include[]"]; !slices.Equal(got, want) {
+		t.Errorf("got include[]=%v, want %v", got, want)
+	}
+
+	incident, _ := row["incident"].(map[string]interface{})
+	if incident["incident_key"] != "latency-1" {
+		t.Errorf("got incident %v, want the full incident", incident)
+	}
+	service, _ := row["service"].(map[string]interface{})
+	if service["name"] != "API Gateway" {
+		t.Errorf("got service %v, want the full service", service)
+	}
+	channel, _ := row["channel"].(map[string]interface{})
+	if channel["type"] != "api" {
+		t.Errorf("got channel %v, want the api channel", channel)
+	}
+	if got := row["incident_id"]; got != fakeserver.IncidentID {
+		t.Errorf("got incident_id %v, want %s", got, fakeserver.IncidentID)
+	}
+}
+
+func TestGetLogEntryWithFilterColumns(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []queryOption
+		want bool
+	}{
+		{
+			name: "team",
+			opts: []queryOption{withQual("team_id", "=", fakeserver.TeamID)},
+			want: true,
+		},
+		{
+			name: "another team",
+			opts: []queryOption{withQual("team_id", "=", "POTHER1")},
+		},
+		{
+			// is_overview isn't a key column of the get, so it is NULL and
+			// the row is filtered out by Postgres
+			name: "overview",
+			opts: []queryOption{withQual("is_overview", "=", true)},
+		},
+		{
+			name: "time zone",
+			opts: []queryOption{withQual("time_zone", "=", "Europe/London")},
+			want: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(t)
+
+			opts := append([]queryOption{withQual("id", "=", fakeserver.LogEntryID)}, test.opts...)
+			row, err := queryRow(t, server, "pagerduty_log_entry", opts...)
+			if err != nil {
+				t.Fatalf("get failed: %v", err)
+			}
+			if !test.want {
+				if row != nil {
+					t.Errorf("got %v, want no row", row)
+				}
+				return
+			}
+			if row == nil || row["id"] != fakeserver.LogEntryID {
+				t.Fatalf("got %v, want log entry %s", row, fakeserver.LogEntryID)
+			}
+
+			// The log entry is fetched directly, not listed
+			if got := len(server.Requests("/log_entries")); got != 0 {
+				t.Errorf("got %d list requests, want none", got)
+			}
+		})
+	}
+}
@@ -19,7 +19,7 @@ func tablePagerDutyOnCall(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			Hydrate: listPagerDutyOnCalls,
 		},
-		Columns: []*plugin.Column{
+		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "escalation_level",
 				Description: "The escalation level for the on-call.",
@@ -40,18 +40,36 @@ func tablePagerDutyOnCall(_ context.Context) *plugin.Table {
 				Description: "The escalation_policy object.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "escalation_policy_id",
+				Description: "An unique identifier of the escalation policy.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("EscalationPolicy").Transform(referenceID),
+			},
 			{
 				Name:        "schedule",
 				Description: "The schedule object.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "schedule_id",
+				Description: "An unique identifier of the schedule.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Schedule").Transform(referenceID),
+			},
 			{
 				Name:        "user_on_call",
 				Description: "The user object.",
 				Type:        proto.ColumnType_JSON,
 				Transform:   transform.FromField("User"),
 			},
-		},
+			{
+				Name:        "user_id",
+				Description: "An unique identifier of the user on call.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("User").Transform(referenceID),
+			},
+		}),
 	}
 }
 
@@ -67,46 +85,35 @@ func listPagerDutyOnCalls(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 
 	req := pagerduty.ListOnCallOptions{}
 
-	// Retrieve the list of on calls
-	maxResult := uint(100)
-
-	// Reduce the basic request limit down if the user has only requested a small number of rows
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if uint(*limit) < maxResult {
-			maxResult = uint(*limit)
-		}
-	}
-	req.APIListObject.Limit = maxResult
-
-	listPage := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-		data, err := client.ListOnCallsWithContext(ctx, req)
-		return data, err
-	}
-	for {
-		listPageResponse, err := plugin.RetryHydrate(ctx, d, h, listPage, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+	// On-calls can't be filtered by team, so filter them by the escalation
+	// policies of the connection's teams instead
+	if teamIDs := configuredTeamIDs(d); len(teamIDs) > 0 {
+		policyIDs, err := listTeamEscalationPolicyIDs(ctx, d, h, client, teamIDs)
 		if err != nil {
-			if isNotFoundError(err) {
-				return nil, nil
-			}
 			plugin.Logger(ctx).Error("pagerduty_on_call.listPagerDutyOnCalls", "query_error", err)
 			return nil, err
 		}
-		listResponse := listPageResponse.(*pagerduty.ListOnCallsResponse)
-
-		for _, oncall := range listResponse.OnCalls {
-			d.StreamListItem(ctx, oncall)
-
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
-			}
+		if len(policyIDs) == 0 {
+			return nil, nil
 		}
+		req.EscalationPolicyIDs = policyIDs
+	}
 
-		if !listResponse.APIListObject.More {
-			break
+	err = paginate(ctx, d, h, func(ctx context.Context, page pageRequest) (*pageResult[pagerduty.OnCall], error) {
+		req.APIListObject.Limit = page.Limit
+		req.APIListObject.Offset = page.Offset
+		resp, err := client.ListOnCallsWithContext(ctx, req)
+		if err != nil {
+			return nil, err
 		}
-		req.APIListObject.Offset = listResponse.APIListObject.Offset + listResponse.APIListObject.Limit
+		return offsetPage(resp.OnCalls, resp.APIListObject), nil
+	})
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("pagerduty_on_call.listPagerDutyOnCalls", "query_error", err)
+		return nil, err
 	}
 
 	return nil, nil
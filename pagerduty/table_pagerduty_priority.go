@@ -18,7 +18,7 @@ func tablePagerDutyPriority(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			Hydrate: listPagerDutyPriorities,
 		},
-		Columns: []*plugin.Column{
+		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "name",
 				Description: "The user-provided short name of the priority.",
@@ -64,7 +64,7 @@ func tablePagerDutyPriority(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromField("Name"),
 			},
-		},
+		}),
 	}
 }
 
@@ -78,11 +78,14 @@ func listPagerDutyPriorities(ctx context.Context, d *plugin.QueryData, h *plugin
 		return nil, err
 	}
 
-	listPage := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-		data, err := client.ListPrioritiesWithContext(ctx)
-		return data, err
-	}
-	listResponse, err := plugin.RetryHydrate(ctx, d, h, listPage, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+	// The client only fetches the first page of priorities, so call the API directly
+	err = paginate(ctx, d, h, func(ctx context.Context, page pageRequest) (*pageResult[pagerduty.PriorityProperty], error) {
+		var resp pagerduty.Priorities
+		if err := client.getJSON(ctx, "/priorities", listQuery(page), &resp); err != nil {
+			return nil, err
+		}
+		return offsetPage(resp.Priorities, resp.APIListObject), nil
+	})
 	if err != nil {
 		// If incident priority level is not enabled, API returns 404 Not Found error
 		if isNotFoundError(err) {
@@ -91,16 +94,6 @@ func listPagerDutyPriorities(ctx context.Context, d *plugin.QueryData, h *plugin
 		plugin.Logger(ctx).Error("pagerduty_priority.listPagerDutyPriorities", "query_error", err)
 		return nil, err
 	}
-	resp := listResponse.(*pagerduty.Priorities)
-
-	for _, priority := range resp.Priorities {
-		d.StreamListItem(ctx, priority)
-
-		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if d.RowsRemaining(ctx) == 0 {
-			return nil, nil
-		}
-	}
 
 	return nil, nil
 }
@@ -3,6 +3,8 @@ package pagerduty
 import (
 	"context"
 
+	"github.com/PagerDuty/go-pagerduty"
+
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -21,7 +23,7 @@ func tablePagerDutyRuleset(_ context.Context) *plugin.Table {
 			Hydrate:    getPagerDutyRuleset,
 			KeyColumns: plugin.SingleColumn("id"),
 		},
-		Columns: []*plugin.Column{
+		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "name",
 				Description: "The name of the ruleset.",
@@ -66,13 +68,13 @@ func tablePagerDutyRuleset(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromField("Name"),
 			},
-		},
+		}),
 	}
 }
 
 //// LIST FUNCTION
 
-func listPagerDutyRulesets(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+func listPagerDutyRulesets(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	// Create client
 	client, err := getSessionConfig(ctx, d)
 	if err != nil {
@@ -80,21 +82,27 @@ func listPagerDutyRulesets(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 		return nil, err
 	}
 
-	resp, err := client.ListRulesetsPaginated(ctx)
+	// The client can only list rulesets by loading every page, so call the API directly
+	err = paginate(ctx, d, h, func(ctx context.Context, page pageRequest) (*pageResult[*pagerduty.Ruleset], error) {
+		page.Limit = filteredPageLimit(page, len(configuredTeamIDs(d)) > 0)
+		var resp pagerduty.ListRulesetsResponse
+		if err := client.getJSON(ctx, "/rulesets", listQuery(page), &resp); err != nil {
+			return nil, err
+		}
+		// The API can't filter rulesets by team
+		rulesets := make([]*pagerduty.Ruleset, 0, len(resp.Rulesets))
+		for _, ruleset := range resp.Rulesets {
+			if inConfiguredTeams(d, rulesetTeamIDs(ruleset)...) {
+				rulesets = append(rulesets, ruleset)
+			}
+		}
+		return offsetPage(rulesets, pagerduty.APIListObject{Limit: resp.Limit, Offset: resp.Offset, More: resp.More}), nil
+	})
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_ruleset.listPagerDutyRulesets", "query_error", err)
 		return nil, err
 	}
 
-	for _, ruleset := range resp {
-		d.StreamListItem(ctx, ruleset)
-
-		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if d.RowsRemaining(ctx) == 0 {
-			return nil, nil
-		}
-	}
-
 	return nil, nil
 }
 
@@ -114,7 +122,7 @@ func getPagerDutyRuleset(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 		return nil, nil
 	}
 
-	data, err := client.GetRulesetWithContext(ctx, id)
+	data, err := getRuleset(ctx, d, h, client, id)
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_ruleset.getPagerDutyRuleset", "query_error", err)
 
@@ -124,5 +132,31 @@ func getPagerDutyRuleset(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 		return nil, err
 	}
 
+	// Hide rulesets outside of the connection's teams
+	if !inConfiguredTeams(d, rulesetTeamIDs(data)...) {
+		return nil, nil
+	}
+
 	return *data, nil
 }
+
+// getRuleset fetches the ruleset, applying the retry policy
+func getRuleset(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, client *pagerDutyClient, id string) (*pagerduty.Ruleset, error) {
+	getDetails := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+		return client.GetRulesetWithContext(ctx, id)
+	}
+	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, retryConfig(d))
+	if err != nil {
+		return nil, err
+	}
+	return getResponse.(*pagerduty.Ruleset), nil
+}
+
+// rulesetTeamIDs returns the ID of the team the ruleset belongs to, if any.
+// Global rulesets belong to no team.
+func rulesetTeamIDs(ruleset *pagerduty.Ruleset) []string {
+	if ruleset.Team == nil {
+		return nil
+	}
+	return []string{ruleset.Team.ID}
+}
@@ -2,6 +2,7 @@ package pagerduty
 
 import (
 	"context"
+	"net/url"
 
 	"github.com/PagerDuty/go-pagerduty"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
@@ -23,7 +24,7 @@ func tablePagerDutyRulesetRule(_ context.Context) *plugin.Table {
 			Hydrate:    getPagerDutyRulesetRule,
 			KeyColumns: plugin.AllColumns([]string{"ruleset_id", "id"}),
 		},
-		Columns: []*plugin.Column{
+		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "id",
 				Description: "The ID of the event rule.",
@@ -80,7 +81,7 @@ func tablePagerDutyRulesetRule(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromField("ID"),
 			},
-		},
+		}),
 	}
 }
 
@@ -102,21 +103,24 @@ func listPagerDutyRulesetRules(ctx context.Context, d *plugin.QueryData, h *plug
 		return nil, err
 	}
 
-	resp, err := client.ListRulesetRulesPaginated(ctx, rulesetData.ID)
+	// The client can only list rules by loading every page, so call the API directly
+	err = paginate(ctx, d, h, func(ctx context.Context, page pageRequest) (*pageResult[rulesetRuleInfo], error) {
+		var resp pagerduty.ListRulesetRulesResponse
+		if err := client.getJSON(ctx, "/rulesets/"+url.PathEscape(rulesetData.ID)+"/rules", listQuery(page), &resp); err != nil {
+			return nil, err
+		}
+
+		rules := make([]rulesetRuleInfo, 0, len(resp.Rules))
+		for _, rule := range resp.Rules {
+			rules = append(rules, rulesetRuleInfo{*rule, rulesetData.ID})
+		}
+		return offsetPage(rules, pagerduty.APIListObject{Limit: resp.Limit, Offset: resp.Offset, More: resp.More}), nil
+	})
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_ruleset_rule.listPagerDutyRulesetRules", "query_error", err)
 		return nil, err
 	}
 
-	for _, rules := range resp {
-		d.StreamListItem(ctx, rulesetRuleInfo{*rules, rulesetData.ID})
-
-		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if d.RowsRemaining(ctx) == 0 {
-			return nil, nil
-		}
-	}
-
 	return nil, nil
 }
 
@@ -137,7 +141,26 @@ func getPagerDutyRulesetRule(ctx context.Context, d *plugin.QueryData, h *plugin
 		return nil, nil
 	}
 
-	data, err := client.GetRulesetRuleWithContext(ctx, rulesetID, ruleID)
+	// Hide the rules of rulesets outside of the connection's teams
+	if len(configuredTeamIDs(d)) > 0 {
+		ruleset, err := getRuleset(ctx, d, h, client, rulesetID)
+		if err != nil {
+			plugin.Logger(ctx).Error("pagerduty_ruleset_rule.getPagerDutyRulesetRule", "query_error", err)
+
+			if isNotFoundError(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		if !inConfiguredTeams(d, rulesetTeamIDs(ruleset)...) {
+			return nil, nil
+		}
+	}
+
+	getDetails := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+		return client.GetRulesetRuleWithContext(ctx, rulesetID, ruleID)
+	}
+	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, retryConfig(d))
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_ruleset_rule.getPagerDutyRulesetRule", "query_error", err)
 
@@ -146,6 +169,7 @@ func getPagerDutyRulesetRule(ctx context.Context, d *plugin.QueryData, h *plugin
 		}
 		return nil, err
 	}
+	data := getResponse.(*pagerduty.RulesetRule)
 
 	return rulesetRuleInfo{*data, rulesetID}, nil
 }
@@ -29,7 +29,7 @@ func tablePagerDutySchedule(_ context.Context) *plugin.Table {
 			Hydrate:    getPagerDutySchedule,
 			KeyColumns: plugin.SingleColumn("id"),
 		},
-		Columns: []*plugin.Column{
+		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "name",
 				Description: "The name of the schedule.",
@@ -78,6 +78,12 @@ func tablePagerDutySchedule(_ context.Context) *plugin.Table {
 				Description: "A list of the escalation policies that uses this schedule.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "escalation_policy_ids",
+				Description: "The IDs of the escalation policies that use this schedule.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("EscalationPolicies").Transform(referenceIDs),
+			},
 			{
 				Name:        "final_schedule",
 				Description: "Specifies the final schedule.",
@@ -102,11 +108,23 @@ func tablePagerDutySchedule(_ context.Context) *plugin.Table {
 				Description: "A list of the teams on the schedule.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "team_ids",
+				Description: "The IDs of the teams on the schedule.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Teams").Transform(referenceIDs),
+			},
 			{
 				Name:        "users",
 				Description: "A list of the users on the schedule.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "user_ids",
+				Description: "The IDs of the users on the schedule.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Users").Transform(referenceIDs),
+			},
 
 			// Steampipe standard columns
 			{
@@ -115,7 +133,7 @@ func tablePagerDutySchedule(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromField("Name"),
 			},
-		},
+		}),
 	}
 }
 
@@ -136,43 +154,26 @@ func listPagerDutySchedules(ctx context.Context, d *plugin.QueryData, h *plugin.
 		req.Query = d.EqualsQuals["name"].GetStringValue()
 	}
 
-	// Retrieve the list of schedules
-	maxResult := uint(100)
-
-	// Reduce the basic request limit down if the user has only requested a small number of rows
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if uint(*limit) < maxResult {
-			maxResult = uint(*limit)
-		}
-	}
-	req.APIListObject.Limit = maxResult
-
-	listPage := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-		schedules, err := client.ListSchedulesWithContext(ctx, req)
-		return schedules, err
-	}
-	for {
-		listPageResponse, err := plugin.RetryHydrate(ctx, d, h, listPage, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+	err = paginate(ctx, d, h, func(ctx context.Context, page pageRequest) (*pageResult[pagerduty.Schedule], error) {
+		req.APIListObject.Limit = filteredPageLimit(page, len(configuredTeamIDs(d)) > 0)
+		req.APIListObject.Offset = page.Offset
+		resp, err := client.ListSchedulesWithContext(ctx, req)
 		if err != nil {
-			plugin.Logger(ctx).Error("pagerduty_schedule.listPagerDutySchedules", "query_error", err)
 			return nil, err
 		}
-		listResponse := listPageResponse.(*pagerduty.ListSchedulesResponse)
 
-		for _, schedule := range listResponse.Schedules {
-			d.StreamListItem(ctx, schedule)
-
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
+		// The API can't filter schedules by team
+		schedules := make([]pagerduty.Schedule, 0, len(resp.Schedules))
+		for _, schedule := range resp.Schedules {
+			if inConfiguredTeams(d, teamReferenceIDs(schedule.Teams)...) {
+				schedules = append(schedules, schedule)
 			}
 		}
-
-		if !listResponse.APIListObject.More {
-			break
-		}
-		req.APIListObject.Offset = listResponse.APIListObject.Offset + listResponse.APIListObject.Limit
+		return offsetPage(schedules, resp.APIListObject), nil
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_schedule.listPagerDutySchedules", "query_error", err)
+		return nil, err
 	}
 
 	return nil, nil
@@ -204,7 +205,7 @@ func getPagerDutySchedule(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 		data, err := client.GetScheduleWithContext(ctx, id, pagerduty.GetScheduleOptions{})
 		return data, err
 	}
-	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, retryConfig(d))
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_schedule.getPagerDutySchedule", "query_error", err)
 
@@ -215,5 +216,10 @@ func getPagerDutySchedule(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 	}
 	getResp := getResponse.(*pagerduty.Schedule)
 
+	// Hide schedules outside of the connection's teams
+	if !inConfiguredTeams(d, teamReferenceIDs(getResp.Teams)...) {
+		return nil, nil
+	}
+
 	return *getResp, nil
 }
@@ -20,7 +20,7 @@ func tablePagerDutyScheduleUser(_ context.Context) *plugin.Table {
 				{Name: "schedule_id", Require: plugin.Optional},
 			},
 		},
-		Columns: []*plugin.Column{
+		Columns: commonColumns([]*plugin.Column{
 			// Schedule Columns
 			{
 				Name:        "schedule_id",
@@ -104,7 +104,7 @@ func tablePagerDutyScheduleUser(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromField("User.Name"),
 			},
-		},
+		}),
 	}
 }
 
@@ -133,7 +133,7 @@ func listPagerDutyScheduleUsers(ctx context.Context, d *plugin.QueryData, h *plu
 		users, err := client.ListOnCallUsersWithContext(ctx, schedule.ID, pagerduty.ListOnCallUsersOptions{})
 		return users, err
 	}
-	listResponse, err := plugin.RetryHydrate(ctx, d, h, listPage, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+	listResponse, err := plugin.RetryHydrate(ctx, d, h, listPage, retryConfig(d))
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_schedule_user.listPagerDutyScheduleUsers", "query_error", err)
 		return nil, err
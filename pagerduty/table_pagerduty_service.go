@@ -29,7 +29,7 @@ func tablePagerDutyService(_ context.Context) *plugin.Table {
 			Hydrate:    getPagerDutyService,
 			KeyColumns: plugin.SingleColumn("id"),
 		},
-		Columns: []*plugin.Column{
+		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "name",
 				Description: "The name of the service.",
@@ -107,6 +107,12 @@ func tablePagerDutyService(_ context.Context) *plugin.Table {
 				Description: "Escalation policy associated with the service.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "escalation_policy_id",
+				Description: "An unique identifier of the escalation policy associated with the service.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("EscalationPolicy").Transform(referenceID),
+			},
 			{
 				Name:        "incident_urgency_rule",
 				Description: "A list of incident urgency rules.",
@@ -132,6 +138,12 @@ func tablePagerDutyService(_ context.Context) *plugin.Table {
 				Description: "The set of teams associated with this service.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "team_ids",
+				Description: "The IDs of the teams associated with this service.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Teams").Transform(referenceIDs),
+			},
 
 			// Steampipe standard columns
 			{
@@ -140,13 +152,13 @@ func tablePagerDutyService(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromField("Name"),
 			},
-		},
+		}),
 	}
 }
 
 //// LIST FUNCTION
 
-func listPagerDutyServices(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+func listPagerDutyServices(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	// Create client
 	client, err := getSessionConfig(ctx, d)
 	if err != nil {
@@ -163,18 +175,6 @@ func listPagerDutyServices(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 		req.Query = d.EqualsQuals["name"].GetStringValue()
 	}
 
-	// Retrieve the list of services
-	maxResult := uint(100)
-
-	// Reduce the basic request limit down if the user has only requested a small number of rows
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if uint(*limit) < maxResult {
-			maxResult = uint(*limit)
-		}
-	}
-	req.APIListObject.Limit = maxResult
-
 	// Check for additional models to include in response
 	// for example, escalation_policy, integrations, teams
 	givenColumns := d.QueryContext.Columns
@@ -182,22 +182,22 @@ func listPagerDutyServices(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 	if len(includeFields) > 0 {
 		req.Includes = includeFields
 	}
+	req.TeamIDs = configuredTeamIDs(d)
 
-	resp, err := client.ListServicesPaginated(ctx, req)
+	err = paginate(ctx, d, h, func(ctx context.Context, page pageRequest) (*pageResult[pagerduty.Service], error) {
+		req.APIListObject.Limit = page.Limit
+		req.APIListObject.Offset = page.Offset
+		resp, err := client.ListServicesWithContext(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return offsetPage(resp.Services, resp.APIListObject), nil
+	})
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_service.listPagerDutyServices", "query_error", err)
 		return nil, err
 	}
 
-	for _, service := range resp {
-		d.StreamListItem(ctx, service)
-
-		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if d.RowsRemaining(ctx) == 0 {
-			return nil, nil
-		}
-	}
-
 	return nil, nil
 }
 
@@ -217,7 +217,10 @@ func getPagerDutyService(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 		return nil, nil
 	}
 
-	data, err := client.GetServiceWithContext(ctx, id, &pagerduty.GetServiceOptions{})
+	getDetails := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+		return client.GetServiceWithContext(ctx, id, &pagerduty.GetServiceOptions{})
+	}
+	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, retryConfig(d))
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_service.getPagerDutyService", "query_error", err)
 
@@ -226,10 +229,36 @@ func getPagerDutyService(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 		}
 		return nil, err
 	}
+	data := getResponse.(*pagerduty.Service)
+
+	// Hide services outside of the connection's teams
+	if !inConfiguredTeams(d, teamIDsOf(data.Teams)...) {
+		return nil, nil
+	}
 
 	return *data, nil
 }
 
+// serviceInConfiguredTeams returns true if the service belongs to one of the
+// connection's teams, or the connection isn't restricted to any teams. A
+// service that doesn't exist belongs to none.
+func serviceInConfiguredTeams(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, client *pagerDutyClient, id string) (bool, error) {
+	if len(configuredTeamIDs(d)) == 0 {
+		return true, nil
+	}
+	getDetails := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+		return client.GetServiceWithContext(ctx, id, &pagerduty.GetServiceOptions{})
+	}
+	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, retryConfig(d))
+	if err != nil {
+		if isNotFoundError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return inConfiguredTeams(d, teamIDsOf(getResponse.(*pagerduty.Service).Teams)...), nil
+}
+
 func buildServiceRequestFields(ctx context.Context, queryColumns []string) []string {
 	var fields []string
 	for _, columnName := range queryColumns {
@@ -30,7 +30,7 @@ func tablePagerDutyServiceIntegration(_ context.Context) *plugin.Table {
 			Hydrate:    getPagerDutyServiceIntegration,
 			KeyColumns: plugin.AllColumns([]string{"service_id", "id"}),
 		},
-		Columns: []*plugin.Column{
+		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "name",
 				Description: "The name of this integration.",
@@ -102,7 +102,7 @@ func tablePagerDutyServiceIntegration(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromField("Name"),
 			},
-		},
+		}),
 	}
 }
 
@@ -131,7 +131,7 @@ func listPagerDutyServiceIntegrations(ctx context.Context, d *plugin.QueryData,
 
 //// HYDRATE FUNCTIONS
 
-func getPagerDutyServiceIntegration(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+func getPagerDutyServiceIntegration(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	// Create client
 	client, err := getSessionConfig(ctx, d)
 	if err != nil {
@@ -146,7 +146,20 @@ func getPagerDutyServiceIntegration(ctx context.Context, d *plugin.QueryData, _
 		return nil, nil
 	}
 
-	data, err := client.GetIntegrationWithContext(ctx, serviceID, id, pagerduty.GetIntegrationOptions{})
+	// Hide the integrations of services outside of the connection's teams
+	ok, err := serviceInConfiguredTeams(ctx, d, h, client, serviceID)
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_service_integration.getPagerDutyServiceIntegration", "query_error", err)
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	getDetails := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+		return client.GetIntegrationWithContext(ctx, serviceID, id, pagerduty.GetIntegrationOptions{})
+	}
+	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, retryConfig(d))
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_service_integration.getPagerDutyServiceIntegration", "query_error", err)
 
@@ -155,6 +168,7 @@ func getPagerDutyServiceIntegration(ctx context.Context, d *plugin.QueryData, _
 		}
 		return nil, err
 	}
+	data := getResponse.(*pagerduty.Integration)
 
 	return *data, nil
 }
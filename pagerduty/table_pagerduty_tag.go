@@ -25,7 +25,7 @@ func tablePagerDutyTag(_ context.Context) *plugin.Table {
 				},
 			},
 		},
-		Columns: []*plugin.Column{
+		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "id",
 				Description: "An unique identifier of a tag.",
@@ -66,7 +66,7 @@ func tablePagerDutyTag(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromField("Label"),
 			},
-		},
+		}),
 	}
 }
 
@@ -80,44 +80,28 @@ func listPagerDutyTags(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 		return nil, err
 	}
 
-	req := pagerduty.ListTagOptions{}
-
 	// Additional Filters
+	var labelQuery string
 	if d.EqualsQuals["label"] != nil {
-		req.Query = d.EqualsQuals["label"].GetStringValue()
+		labelQuery = d.EqualsQuals["label"].GetStringValue()
 	}
 
-	// Retrieve the list of tags
-	maxResult := uint(100)
-
-	// Reduce the basic request limit down if the user has only requested a small number of rows
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if uint(*limit) < maxResult {
-			maxResult = uint(*limit)
+	// The client can only list tags by loading every page, so call the API directly
+	err = paginate(ctx, d, h, func(ctx context.Context, page pageRequest) (*pageResult[*pagerduty.Tag], error) {
+		query := listQuery(page)
+		if labelQuery != "" {
+			query.Set("query", labelQuery)
 		}
-	}
-	req.APIListObject.Limit = maxResult
-
-	listPage := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-		data, err := client.ListTagsPaginated(ctx, req)
-		return data, err
-	}
-	listResponse, err := plugin.RetryHydrate(ctx, d, h, listPage, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+		var resp pagerduty.ListTagResponse
+		if err := client.getJSON(ctx, "/tags", query, &resp); err != nil {
+			return nil, err
+		}
+		return offsetPage(resp.Tags, resp.APIListObject), nil
+	})
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_tag.listPagerDutyTags", "query_error", err)
 		return nil, err
 	}
-	tags := listResponse.([]*pagerduty.Tag)
-
-	for _, tag := range tags {
-		d.StreamListItem(ctx, tag)
-
-		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if d.RowsRemaining(ctx) == 0 {
-			return nil, nil
-		}
-	}
 
 	return nil, nil
 }
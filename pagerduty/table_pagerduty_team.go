@@ -29,7 +29,7 @@ func tablePagerDutyTeam(_ context.Context) *plugin.Table {
 			Hydrate:    getPagerDutyTeam,
 			KeyColumns: plugin.SingleColumn("id"),
 		},
-		Columns: []*plugin.Column{
+		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "name",
 				Description: "The name of the team.",
@@ -89,7 +89,7 @@ func tablePagerDutyTeam(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromField("Name"),
 			},
-		},
+		}),
 	}
 }
 
@@ -110,43 +110,26 @@ func listPagerDutyTeams(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 		req.Query = d.EqualsQuals["name"].GetStringValue()
 	}
 
-	// Retrieve the list of teams
-	maxResult := uint(100)
-
-	// Reduce the basic request limit down if the user has only requested a small number of rows
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if uint(*limit) < maxResult {
-			maxResult = uint(*limit)
-		}
-	}
-	req.APIListObject.Limit = maxResult
-
-	listPage := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-		teams, err := client.ListTeamsWithContext(ctx, req)
-		return teams, err
-	}
-	for {
-		listPageResponse, err := plugin.RetryHydrate(ctx, d, h, listPage, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+	err = paginate(ctx, d, h, func(ctx context.Context, page pageRequest) (*pageResult[pagerduty.Team], error) {
+		req.APIListObject.Limit = filteredPageLimit(page, len(configuredTeamIDs(d)) > 0)
+		req.APIListObject.Offset = page.Offset
+		resp, err := client.ListTeamsWithContext(ctx, req)
 		if err != nil {
-			plugin.Logger(ctx).Error("pagerduty_team.listPagerDutyTeams", "query_error", err)
 			return nil, err
 		}
-		listResponse := listPageResponse.(*pagerduty.ListTeamResponse)
 
-		for _, team := range listResponse.Teams {
-			d.StreamListItem(ctx, team)
-
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
+		// The API can't filter teams by ID
+		teams := make([]pagerduty.Team, 0, len(resp.Teams))
+		for _, team := range resp.Teams {
+			if inConfiguredTeams(d, team.ID) {
+				teams = append(teams, team)
 			}
 		}
-
-		if !listResponse.APIListObject.More {
-			break
-		}
-		req.APIListObject.Offset = listResponse.APIListObject.Offset + listResponse.APIListObject.Limit
+		return offsetPage(teams, resp.APIListObject), nil
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_team.listPagerDutyTeams", "query_error", err)
+		return nil, err
 	}
 
 	return nil, nil
@@ -163,8 +146,8 @@ func getPagerDutyTeam(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	}
 	id := d.EqualsQuals["id"].GetStringValue()
 
-	// No inputs
-	if id == "" {
+	// No inputs, or a team outside of the connection's teams
+	if id == "" || !inConfiguredTeams(d, id) {
 		return nil, nil
 	}
 
@@ -172,7 +155,7 @@ func getPagerDutyTeam(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 		data, err := client.GetTeamWithContext(ctx, id)
 		return data, err
 	}
-	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, retryConfig(d))
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_team.getPagerDutyTeam", "query_error", err)
 
@@ -200,7 +183,7 @@ func listPagerDutyTeamMembers(ctx context.Context, d *plugin.QueryData, h *plugi
 		data, err := client.ListMembersPaginated(ctx, data.ID)
 		return data, err
 	}
-	listResponse, err := plugin.RetryHydrate(ctx, d, h, listPage, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+	listResponse, err := plugin.RetryHydrate(ctx, d, h, listPage, retryConfig(d))
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_team.listPagerDutyTeams", "query_error", err)
 		return nil, err
@@ -224,7 +207,7 @@ func listPagerDutyTeamTags(ctx context.Context, d *plugin.QueryData, h *plugin.H
 		data, err := client.GetTagsForEntityPaginated(ctx, "teams", data.ID, pagerduty.ListTagOptions{})
 		return data, err
 	}
-	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, retryConfig(d))
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_team.listPagerDutyTeamTags", "query_error", err)
 		return nil, err
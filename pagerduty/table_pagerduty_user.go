@@ -27,13 +27,17 @@ func tablePagerDutyUser(_ context.Context) *plugin.Table {
 					Name:    "name",
 					Require: plugin.Optional,
 				},
+				{
+					Name:    "role",
+					Require: plugin.Optional,
+				},
 			},
 		},
 		Get: &plugin.GetConfig{
 			Hydrate:    getPagerDutyUser,
 			KeyColumns: plugin.SingleColumn("id"),
 		},
-		Columns: []*plugin.Column{
+		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "name",
 				Description: "The name of the user.",
@@ -129,6 +133,12 @@ func tablePagerDutyUser(_ context.Context) *plugin.Table {
 				Description: "A list of teams to which the user belongs. Account must have the teams ability to set this.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "team_ids",
+				Description: "The IDs of the teams to which the user belongs.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Teams").Transform(referenceIDs),
+			},
 
 			// Steampipe standard columns
 			{
@@ -137,7 +147,7 @@ func tablePagerDutyUser(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromField("Name"),
 			},
-		},
+		}),
 	}
 }
 
@@ -161,18 +171,6 @@ func listPagerDutyUsers(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 		req.Query = d.EqualsQuals["name"].GetStringValue()
 	}
 
-	// Retrieve the list of users
-	maxResult := uint(100)
-
-	// Reduce the basic request limit down if the user has only requested a small number of rows
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if uint(*limit) < maxResult {
-			maxResult = uint(*limit)
-		}
-	}
-	req.APIListObject.Limit = maxResult
-
 	// Check for additional models to include in response
 	// for example, contact_methods, notification_rules, teams
 	givenColumns := d.QueryContext.Columns
@@ -181,32 +179,30 @@ func listPagerDutyUsers(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 	if len(includeFields) > 0 {
 		req.Includes = includeFields
 	}
+	req.TeamIDs = configuredTeamIDs(d)
 
-	listPage := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-		users, err := client.ListUsersWithContext(ctx, req)
-		return users, err
-	}
-	for {
-		listPageResponse, err := plugin.RetryHydrate(ctx, d, h, listPage, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+	// The API can't filter users by role, but filtering them here lets a
+	// query's LIMIT stop the listing early
+	roles := enumQualFilter(d, "role")
+
+	err = paginate(ctx, d, h, func(ctx context.Context, page pageRequest) (*pageResult[pagerduty.User], error) {
+		req.APIListObject.Limit = filteredPageLimit(page, roles.in != nil)
+		req.APIListObject.Offset = page.Offset
+		resp, err := client.ListUsersWithContext(ctx, req)
 		if err != nil {
-			plugin.Logger(ctx).Error("pagerduty_user.listPagerDutyUsers", "query_error", err)
 			return nil, err
 		}
-		listResponse := listPageResponse.(*pagerduty.ListUsersResponse)
-
-		for _, user := range listResponse.Users {
-			d.StreamListItem(ctx, user)
-
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
+		users := make([]pagerduty.User, 0, len(resp.Users))
+		for _, user := range resp.Users {
+			if roles.matches(user.Role) {
+				users = append(users, user)
 			}
 		}
-
-		if !listResponse.APIListObject.More {
-			break
-		}
-		req.APIListObject.Offset = listResponse.APIListObject.Offset + listResponse.APIListObject.Limit
+		return offsetPage(users, resp.APIListObject), nil
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_user.listPagerDutyUsers", "query_error", err)
+		return nil, err
 	}
 
 	return nil, nil
@@ -232,7 +228,7 @@ func getPagerDutyUser(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 		data, err := client.GetUserWithContext(ctx, id, pagerduty.GetUserOptions{})
 		return data, err
 	}
-	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, retryConfig(d))
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_user.getPagerDutyUser", "query_error", err)
 
@@ -243,6 +239,11 @@ func getPagerDutyUser(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	}
 	getResp := getResponse.(*pagerduty.User)
 
+	// Hide users outside of the connection's teams
+	if !inConfiguredTeams(d, teamIDsOf(getResp.Teams)...) {
+		return nil, nil
+	}
+
 	return *getResp, nil
 }
 
@@ -260,7 +261,7 @@ func listPagerDutyUserTags(ctx context.Context, d *plugin.QueryData, h *plugin.H
 		data, err := client.GetTagsForEntityPaginated(ctx, "users", data.ID, pagerduty.ListTagOptions{})
 		return data, err
 	}
-	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, retryConfig(d))
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_user.listPagerDutyUserTags", "query_error", err)
 		return nil, err
@@ -29,7 +29,7 @@ func tablePagerDutyVendor(_ context.Context) *plugin.Table {
 			Hydrate:    getPagerDutyVendor,
 			KeyColumns: plugin.SingleColumn("id"),
 		},
-		Columns: []*plugin.Column{
+		Columns: commonColumns([]*plugin.Column{
 			{
 				Name:        "name",
 				Description: "The name of the vendor.",
@@ -125,7 +125,7 @@ func tablePagerDutyVendor(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromField("Name"),
 			},
-		},
+		}),
 	}
 }
 
@@ -146,43 +146,18 @@ func listPagerDutyVendors(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 		req.Query = d.EqualsQuals["name"].GetStringValue()
 	}
 
-	// Retrieve the list of vendors
-	maxResult := uint(100)
-
-	// Reduce the basic request limit down if the user has only requested a small number of rows
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if uint(*limit) < maxResult {
-			maxResult = uint(*limit)
-		}
-	}
-	req.APIListObject.Limit = maxResult
-
-	listPage := func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-		vendors, err := client.ListVendorsWithContext(ctx, req)
-		return vendors, err
-	}
-	for {
-		listPageResponse, err := plugin.RetryHydrate(ctx, d, h, listPage, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+	err = paginate(ctx, d, h, func(ctx context.Context, page pageRequest) (*pageResult[pagerduty.Vendor], error) {
+		req.APIListObject.Limit = page.Limit
+		req.APIListObject.Offset = page.Offset
+		resp, err := client.ListVendorsWithContext(ctx, req)
 		if err != nil {
-			plugin.Logger(ctx).Error("pagerduty_vendor.listPagerDutyVendors", "query_error", err)
 			return nil, err
 		}
-		listResponse := listPageResponse.(*pagerduty.ListVendorResponse)
-
-		for _, vendor := range listResponse.Vendors {
-			d.StreamListItem(ctx, vendor)
-
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
-			}
-		}
-
-		if !listResponse.APIListObject.More {
-			break
-		}
-		req.APIListObject.Offset = listResponse.APIListObject.Offset + listResponse.APIListObject.Limit
+		return offsetPage(resp.Vendors, resp.APIListObject), nil
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("pagerduty_vendor.listPagerDutyVendors", "query_error", err)
+		return nil, err
 	}
 
 	return nil, nil
@@ -208,7 +183,7 @@ func getPagerDutyVendor(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 		data, err := client.GetVendorWithContext(ctx, id)
 		return data, err
 	}
-	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, &plugin.RetryConfig{ShouldRetryError: shouldRetryError})
+	getResponse, err := plugin.RetryHydrate(ctx, d, h, getDetails, retryConfig(d))
 	if err != nil {
 		plugin.Logger(ctx).Error("pagerduty_vendor.getPagerDutyVendor", "query_error", err)
 
@@ -0,0 +1,165 @@
+package pagerduty
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-pagerduty/internal/fakeserver"
+)
+
+func TestListTables(t *testing.T) {
+	tests := []struct {
+		table string
+		opts  []queryOption
+		ids   []string
+	}{
+		{table: "pagerduty_escalation_policy", ids: []string{fakeserver.EscalationPolicyID}},
+		{table: "pagerduty_incident", ids: []string{fakeserver.IncidentID, fakeserver.SecondIncidentID}},
+		{
+			table: "pagerduty_incident_alert",
+			opts:  []queryOption{withFixtureIncidents()},
+			ids:   []string{fakeserver.AlertID, fakeserver.SecondAlertID},
+		},
+		{
+			table: "pagerduty_incident_log",
+			opts:  []queryOption{withQual("incident_id", "=", fakeserver.IncidentID)},
+			ids:   []string{fakeserver.LogEntryID},
+		},
+		{
+			table: "pagerduty_incident_note",
+			opts:  []queryOption{withFixtureIncidents()},
+			ids:   []string{fakeserver.NoteID},
+		},
+		{table: "pagerduty_log_entry", ids: []string{fakeserver.LogEntryID, fakeserver.SecondLogEntryID}},
+		{table: "pagerduty_priority", ids: []string{fakeserver.PriorityID}},
+		{table: "pagerduty_ruleset", ids: []string{fakeserver.RulesetID}},
+		{table: "pagerduty_ruleset_rule", ids: []string{fakeserver.RulesetRuleID}},
+		{table: "pagerduty_schedule", ids: []string{fakeserver.ScheduleID}},
+		{table: "pagerduty_schedule_user", ids: []string{fakeserver.UserID}},
+		{table: "pagerduty_service", ids: []string{fakeserver.ServiceID}},
+		{table: "pagerduty_service_integration", ids: []string{fakeserver.IntegrationID}},
+		{table: "pagerduty_tag", ids: []string{fakeserver.TagID}},
+		{table: "pagerduty_team", ids: []string{fakeserver.TeamID}},
+		{table: "pagerduty_user", ids: []string{fakeserver.UserID, fakeserver.SecondUserID}},
+		{table: "pagerduty_vendor", ids: []string{fakeserver.VendorID}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.table, func(t *testing.T) {
+			server := newTestServer(t)
+
+			rows, err := queryRows(t, server, test.table, test.opts...)
+			if err != nil {
+				t.Fatalf("list failed: %v", err)
+			}
+			if len(rows) != len(test.ids) {
+				t.Fatalf("got %d rows, want %d", len(rows), len(test.ids))
+			}
+			for _, id := range test.ids {
+				findRow(t, rows, "id", id)
+			}
+		})
+	}
+}
+
+func TestListOnCalls(t *testing.T) {
+	server := newTestServer(t)
+
+	rows, err := queryRows(t, server, "pagerduty_on_call")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	user, _ := rows[0]["user_on_call"].(map[string]interface{})
+	if user["id"] != fakeserver.UserID {
+		t.Errorf("got on-call user %v, want %s", user["id"], fakeserver.UserID)
+	}
+}
+
+func TestGetTables(t *testing.T) {
+	tests := []struct {
+		table string
+		keys  map[string]string
+	}{
+		{table: "pagerduty_escalation_policy", keys: map[string]string{"id": fakeserver.EscalationPolicyID}},
+		{table: "pagerduty_incident", keys: map[string]string{"id": fakeserver.IncidentID}},
+		{table: "pagerduty_incident_alert", keys: map[string]string{"incident_id": fakeserver.IncidentID, "id": fakeserver.AlertID}},
+		{table: "pagerduty_log_entry", keys: map[string]string{"id": fakeserver.LogEntryID}},
+		{table: "pagerduty_ruleset", keys: map[string]string{"id": fakeserver.RulesetID}},
+		{table: "pagerduty_ruleset_rule", keys: map[string]string{"ruleset_id": fakeserver.RulesetID, "id": fakeserver.RulesetRuleID}},
+		{table: "pagerduty_schedule", keys: map[string]string{"id": fakeserver.ScheduleID}},
+		{table: "pagerduty_service", keys: map[string]string{"id": fakeserver.ServiceID}},
+		{table: "pagerduty_service_integration", keys: map[string]string{"service_id": fakeserver.ServiceID, "id": fakeserver.IntegrationID}},
+		{table: "pagerduty_team", keys: map[string]string{"id": fakeserver.TeamID}},
+		{table: "pagerduty_user", keys: map[string]string{"id": fakeserver.UserID}},
+		{table: "pagerduty_vendor", keys: map[string]string{"id": fakeserver.VendorID}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.table, func(t *testing.T) {
+			server := newTestServer(t)
+
+			var opts []queryOption
+			for column, value := range test.keys {
+				opts = append(opts, withQual(column, "=", value))
+			}
+			row, err := queryRow(t, server, test.table, opts...)
+			if err != nil {
+				t.Fatalf("get failed: %v", err)
+			}
+			if row == nil {
+				t.Fatal("got no row")
+			}
+			if id := row["id"]; id != test.keys["id"] {
+				t.Errorf("got id %v, want %s", id, test.keys["id"])
+			}
+
+			// A missing resource is no row, rather than an error
+			opts = append(opts, withQual("id", "=", "PMISSING"))
+			row, err = queryRow(t, server, test.table, opts...)
+			if err != nil {
+				t.Fatalf("get of a missing resource failed: %v", err)
+			}
+			if row != nil {
+				t.Errorf("got %v for a missing resource, want no row", row)
+			}
+		})
+	}
+}
+
+func TestHydrateTeamMembers(t *testing.T) {
+	server := newTestServer(t)
+
+	team, err := queryRow(t, server, "pagerduty_team", withQual("id", "=", fakeserver.TeamID), withColumns("id", "members"))
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if team == nil {
+		t.Fatal("got no row")
+	}
+	members, _ := team["members"].([]interface{})
+	if len(members) != 1 {
+		t.Fatalf("got members %v, want 1", team["members"])
+	}
+	if role := members[0].(map[string]interface{})["role"]; role != "manager" {
+		t.Errorf("got role %v, want manager", role)
+	}
+}
+
+func TestListUsersByRole(t *testing.T) {
+	server := newTestServer(t)
+
+	// With a LIMIT, the users must be filtered before they are returned
+	rows, err := queryRows(t, server, "pagerduty_user", withQual("role", "=", "user"), withLimit(1))
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0]["id"] != fakeserver.SecondUserID {
+		t.Errorf("got %v, want user %s", rows, fakeserver.SecondUserID)
+	}
+	// and full pages are fetched, as the LIMIT counts the filtered users
+	if got := server.Requests("/users")[0].Query.Get("limit"); got != "100" {
+		t.Errorf("got limit=%s, want 100", got)
+	}
+}
@@ -0,0 +1,56 @@
+package pagerduty
+
+import (
+	"strings"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// configuredTeamIDs returns the team_ids setting of the connection, or nil if
+// the connection isn't restricted to any teams
+func configuredTeamIDs(d *plugin.QueryData) []string {
+	config := GetConfig(d.Connection)
+	var teamIDs []string
+	for _, id := range config.TeamIDs {
+		if id = strings.TrimSpace(id); id != "" {
+			teamIDs = append(teamIDs, id)
+		}
+	}
+	return teamIDs
+}
+
+// inConfiguredTeams returns true if the connection isn't restricted to any
+// teams, or one of teamIDs is in its team_ids setting
+func inConfiguredTeams(d *plugin.QueryData, teamIDs ...string) bool {
+	configured := configuredTeamIDs(d)
+	if len(configured) == 0 {
+		return true
+	}
+	for _, id := range teamIDs {
+		for _, c := range configured {
+			if id == c {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// teamIDsOf returns the IDs of the teams
+func teamIDsOf(teams []pagerduty.Team) []string {
+	ids := make([]string, 0, len(teams))
+	for _, team := range teams {
+		ids = append(ids, team.ID)
+	}
+	return ids
+}
+
+// teamReferenceIDs returns the IDs of the team references
+func teamReferenceIDs(teams []pagerduty.APIReference) []string {
+	ids := make([]string, 0, len(teams))
+	for _, team := range teams {
+		ids = append(ids, team.ID)
+	}
+	return ids
+}
@@ -0,0 +1,68 @@
+package pagerduty
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// referenceID returns the ID of the object or reference in the value, e.g. an
+// incident's service, or nil if there is none
+func referenceID(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	if id := objectID(reflect.ValueOf(d.Value)); id != "" {
+		return id, nil
+	}
+	return nil, nil
+}
+
+// referenceIDs returns the IDs of the objects or references in the list in
+// the value. If the param is set, it is the field of each item that holds the
+// reference, e.g. "Assignee" for an incident's assignments.
+func referenceIDs(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	list := reflect.ValueOf(d.Value)
+	for list.Kind() == reflect.Ptr || list.Kind() == reflect.Interface {
+		list = list.Elem()
+	}
+	if list.Kind() != reflect.Slice || list.IsNil() {
+		return nil, nil
+	}
+
+	field, _ := d.Param.(string)
+	ids := []string{}
+	for i := 0; i < list.Len(); i++ {
+		item := list.Index(i)
+		if field != "" {
+			item = structField(item, field)
+		}
+		if id := objectID(item); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// objectID returns the ID field of the struct in v, which can be a pointer,
+// or "" if there is none
+func objectID(v reflect.Value) string {
+	id := structField(v, "ID")
+	if id.Kind() != reflect.String {
+		return ""
+	}
+	return id.String()
+}
+
+// structField returns the named field of the struct in v, which can be a
+// pointer. The result is invalid if v is nil or has no such field.
+func structField(v reflect.Value, name string) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return v.FieldByName(name)
+}
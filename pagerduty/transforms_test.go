@@ -0,0 +1,110 @@
+package pagerduty
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-pagerduty/internal/fakeserver"
+)
+
+func TestReferenceIDColumns(t *testing.T) {
+	server := newTestServer(t)
+
+	teamIDs := []interface{}{fakeserver.TeamID}
+	tests := []struct {
+		table   string
+		opts    []queryOption
+		id      string
+		columns map[string]interface{}
+	}{
+		{
+			table: "pagerduty_incident",
+			id:    fakeserver.IncidentID,
+			columns: map[string]interface{}{
+				"service_id":           fakeserver.ServiceID,
+				"escalation_policy_id": fakeserver.EscalationPolicyID,
+				"priority_id":          fakeserver.PriorityID,
+				"team_ids":             teamIDs,
+				"assignee_ids":         []interface{}{},
+			},
+		},
+		{
+			table: "pagerduty_incident",
+			id:    fakeserver.SecondIncidentID,
+			columns: map[string]interface{}{
+				"priority_id":  nil,
+				"assignee_ids": []interface{}{fakeserver.UserID},
+			},
+		},
+		{
+			table: "pagerduty_service",
+			columns: map[string]interface{}{
+				"escalation_policy_id": fakeserver.EscalationPolicyID,
+				"team_ids":             teamIDs,
+			},
+		},
+		{
+			table: "pagerduty_escalation_policy",
+			columns: map[string]interface{}{
+				"service_ids": []interface{}{fakeserver.ServiceID},
+				"team_ids":    teamIDs,
+			},
+		},
+		{
+			table: "pagerduty_schedule",
+			columns: map[string]interface{}{
+				"escalation_policy_ids": []interface{}{fakeserver.EscalationPolicyID},
+				"user_ids":              []interface{}{fakeserver.UserID},
+				"team_ids":              teamIDs,
+			},
+		},
+		{
+			table: "pagerduty_on_call",
+			columns: map[string]interface{}{
+				"escalation_policy_id": fakeserver.EscalationPolicyID,
+				"schedule_id":          fakeserver.ScheduleID,
+				"user_id":              fakeserver.UserID,
+			},
+		},
+		{
+			table: "pagerduty_user",
+			id:    fakeserver.UserID,
+			columns: map[string]interface{}{
+				"team_ids": teamIDs,
+			},
+		},
+		{
+			table: "pagerduty_user",
+			id:    fakeserver.SecondUserID,
+			columns: map[string]interface{}{
+				"team_ids": nil,
+			},
+		},
+		{
+			table: "pagerduty_incident_log",
+			opts:  []queryOption{withQual("incident_id", "=", fakeserver.IncidentID)},
+			columns: map[string]interface{}{
+				"team_ids": teamIDs,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		rows, err := queryRows(t, server, test.table, test.opts...)
+		if err != nil {
+			t.Fatalf("list of %s failed: %v", test.table, err)
+		}
+		if len(rows) == 0 {
+			t.Fatalf("got no rows of %s", test.table)
+		}
+		row := rows[0]
+		if test.id != "" {
+			row = findRow(t, rows, "id", test.id)
+		}
+		for column, want := range test.columns {
+			if got := row[column]; !reflect.DeepEqual(got, want) {
+				t.Errorf("got %s.%s = %#v, want %#v", test.table, column, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// defaultMaxRequestsPerMinute keeps the plugin just under the PagerDuty REST
+// API limit of 960 requests per minute
+const defaultMaxRequestsPerMinute = 900
+
+// rateLimitedTransport paces the requests of a single connection so that they
+// stay under the PagerDuty REST API rate limit, rather than relying on 429
+// responses and retries.
+//
+// Requests are spread evenly using a token bucket of max_requests_per_minute.
+// The bucket is slowed down further using the ratelimit-remaining and
+// ratelimit-reset response headers, so that the remaining budget is spread
+// over the rest of the rate limit window. If the budget is exhausted, requests
+// are held back until the window resets.
+type rateLimitedTransport struct {
+	base     http.RoundTripper
+	limiter  *rate.Limiter
+	maxLimit rate.Limit
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+func newRateLimitedTransport(base http.RoundTripper, requestsPerMinute int) *rateLimitedTransport {
+	if requestsPerMinute <= 0 {
+		requestsPerMinute = defaultMaxRequestsPerMinute
+	}
+	maxLimit := rate.Limit(float64(requestsPerMinute) / 60)
+
+	// Allow up to a second's worth of requests in a burst
+	burst := requestsPerMinute / 60
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimitedTransport{
+		base:     base,
+		limiter:  rate.NewLimiter(maxLimit, burst),
+		maxLimit: maxLimit,
+	}
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	t.observe(resp)
+
+	return resp, nil
+}
+
+// wait blocks until the request can be sent, or the context is done
+func (t *rateLimitedTransport) wait(ctx context.Context) error {
+	t.mu.Lock()
+	pause := time.Until(t.pausedUntil)
+	t.mu.Unlock()
+
+	if pause > 0 {
+		timer := time.NewTimer(pause)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return t.limiter.Wait(ctx)
+}
+
+// observe adjusts the pace of subsequent requests using the rate limit
+// headers of the response
+func (t *rateLimitedTransport) observe(resp *http.Response) {
+	// Honour the server's request to back off, which the retry policy relies on
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		if retryAfter, ok := retryAfterDuration(resp.Header); ok {
+			t.pauseFor(retryAfter)
+			return
+		}
+	}
+
+	remaining, hasRemaining := headerInt(resp.Header, "ratelimit-remaining")
+	reset, hasReset := headerInt(resp.Header, "ratelimit-reset")
+
+	// Hold back all requests until the window resets
+	if resp.StatusCode == http.StatusTooManyRequests || (hasRemaining && remaining <= 0) {
+		pause := time.Minute
+		if hasReset && reset > 0 {
+			pause = time.Duration(reset) * time.Second
+		}
+		t.pauseFor(pause)
+		return
+	}
+
+	if !hasRemaining || !hasReset || reset <= 0 {
+		return
+	}
+
+	// Spread the remaining budget over the rest of the window
+	limit := rate.Limit(float64(remaining) / float64(reset))
+	if limit > t.maxLimit {
+		limit = t.maxLimit
+	}
+	t.limiter.SetLimit(limit)
+}
+
+// pauseFor holds back all requests for the given duration
+func (t *rateLimitedTransport) pauseFor(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(t.pausedUntil) {
+		t.pausedUntil = until
+	}
+}
+
+// retryAfterDuration returns the delay requested by the Retry-After header,
+// which is either a number of seconds or an HTTP date
+func retryAfterDuration(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return time.Until(at), true
+	}
+	return 0, false
+}
+
+// headerInt returns the integer value of the header, if it is set
+func headerInt(header http.Header, key string) (int, bool) {
+	value := header.Get(key)
+	if value == "" {
+		return 0, false
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return i, true
+}